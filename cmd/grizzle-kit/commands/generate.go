@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"unicode"
 
+	"github.com/golshani-mhd/grizzle-kit/flavors"
 	"github.com/golshani-mhd/grizzle-kit/generator"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -23,7 +26,9 @@ This command can work in two modes:
 Examples:
   grizzle generate --input ./internal/domain/user/user_schema.go --output gen/grizzle/schema
   grizzle generate --config grizzle.yaml
-  grizzle generate --input ./schema --output gen/grizzle/schema --recursive`,
+  grizzle generate --input ./schema --output gen/grizzle/schema --recursive
+  grizzle generate --config grizzle.yaml --flavor postgres,mysql
+  grizzle generate --config grizzle.yaml --flavor postgres --sql-out migrations/schema.sql`,
 	RunE: runGenerate,
 }
 
@@ -33,6 +38,8 @@ var (
 	recursive   bool
 	entityName  string
 	packageName string
+	flavorNames []string
+	sqlOut      string
 )
 
 func init() {
@@ -44,6 +51,8 @@ func init() {
 	generateCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Process directories recursively")
 	generateCmd.Flags().StringVar(&entityName, "entity", "", "Entity name (if not specified, will be inferred from schema)")
 	generateCmd.Flags().StringVar(&packageName, "package", "", "Package name for generated code (if not specified, will be inferred)")
+	generateCmd.Flags().StringSliceVar(&flavorNames, "flavor", nil, "Flavor(s) to generate for, e.g. postgres or postgres,mysql (overrides generate.flavor)")
+	generateCmd.Flags().StringVar(&sqlOut, "sql-out", "", "Write CREATE statements to a .sql file, or one file per table in a directory (overrides generate.sql_out)")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
@@ -80,11 +89,21 @@ func runGenerateDirect() error {
 	}
 
 	// Process input
-	if info.IsDir() {
-		return processDirectory(inputFile, outputDir, recursive)
-	} else {
-		return processFile(inputFile, outputDir)
+	configs, err := flavorConfigs(outputDir, flavorNames, sqlOut)
+	if err != nil {
+		return err
+	}
+	for _, genConfig := range configs {
+		if info.IsDir() {
+			err = processDirectory(inputFile, genConfig, recursive)
+		} else {
+			err = processFile(inputFile, genConfig)
+		}
+		if err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 func runGenerateFromConfig() error {
@@ -101,6 +120,21 @@ func runGenerateFromConfig() error {
 		output = "gen/grizzle/schema"
 	}
 
+	// Optional flavor(s) used to quote generated identifiers, given as a name or a list;
+	// the --flavor flag takes precedence
+	names := flavorNames
+	if len(names) == 0 {
+		names = viper.GetStringSlice("generate.flavor")
+	}
+	sqlPath := sqlOut
+	if sqlPath == "" {
+		sqlPath = viper.GetString("generate.sql_out")
+	}
+	configs, err := flavorConfigs(output, names, sqlPath)
+	if err != nil {
+		return fmt.Errorf("invalid flavor in config: %w", err)
+	}
+
 	// Create output directory
 	if err := os.MkdirAll(output, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
@@ -112,17 +146,72 @@ func runGenerateFromConfig() error {
 		return fmt.Errorf("input path does not exist: %w", err)
 	}
 
-	if info.IsDir() {
-		recursive := config["recursive"].(bool)
-		return processDirectory(input, output, recursive)
-	} else {
-		return processFile(input, output)
+	for _, genConfig := range configs {
+		if info.IsDir() {
+			recursive := config["recursive"].(bool)
+			err = processDirectory(input, genConfig, recursive)
+		} else {
+			err = processFile(input, genConfig)
+		}
+		if err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func processFile(filePath, outputDir string) error {
+// flavorConfigs returns one generator config per flavor. A single flavor generates into output;
+// several flavors each generate into a sub-package of output named after the flavor, e.g.
+// output/postgresql, with their own model package in output/postgresql/model. SQL written to
+// sqlOut is split by flavor the same way, e.g. sql/postgresql/schema.sql.
+func flavorConfigs(output string, names []string, sqlOut string) ([]*generator.GeneratorConfig, error) {
+	overrides := viper.GetStringMapString("type_overrides")
+	var configs []*generator.GeneratorConfig
+	for _, name := range strings.Split(strings.Join(names, ","), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		flavor, err := flavors.ParseFlavor(name)
+		if err != nil {
+			return nil, err
+		}
+		pkg := flavorPackage(flavor)
+		dir := filepath.Join(output, pkg)
+		config := &generator.GeneratorConfig{OutputDir: dir, ModelDir: filepath.Join(dir, "model"), Flavor: name, TypeOverrides: overrides}
+		if sqlOut != "" && strings.HasSuffix(sqlOut, ".sql") {
+			config.SQLOut = filepath.Join(filepath.Dir(sqlOut), pkg, filepath.Base(sqlOut))
+		} else if sqlOut != "" {
+			config.SQLOut = filepath.Join(sqlOut, pkg)
+		}
+		configs = append(configs, config)
+	}
+	switch len(configs) {
+	case 0:
+		if sqlOut != "" {
+			return nil, fmt.Errorf("a flavor is required to write SQL, use --flavor or generate.flavor")
+		}
+		return []*generator.GeneratorConfig{{OutputDir: output, TypeOverrides: overrides}}, nil
+	case 1:
+		configs[0].OutputDir, configs[0].ModelDir, configs[0].SQLOut = output, "", sqlOut
+	}
+	return configs, nil
+}
+
+// flavorPackage returns the package name of a flavor's generated code, e.g. postgresql or mysql8016
+// for mysql@8.0.16
+func flavorPackage(flavor flavors.Flavor) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, flavor.String())
+}
+
+func processFile(filePath string, config *generator.GeneratorConfig) error {
 	// Generate from file using public generator
-	entities, err := generator.GenerateFromFile(filePath, outputDir)
+	entities, err := generator.NewGenerator(config).GenerateFromFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to generate from file %s: %w", filePath, err)
 	}
@@ -136,7 +225,7 @@ func processFile(filePath, outputDir string) error {
 	return nil
 }
 
-func processDirectory(dirPath, outputDir string, recursive bool) error {
+func processDirectory(dirPath string, config *generator.GeneratorConfig, recursive bool) error {
 	var files []string
 
 	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
@@ -161,25 +250,25 @@ func processDirectory(dirPath, outputDir string, recursive bool) error {
 		return fmt.Errorf("failed to walk directory: %w", err)
 	}
 
-	// Process each file using public generator
+	// Parse every file together so that relations and objects across files can be resolved
+	gen := generator.NewGenerator(config)
+	parsed, err := gen.ParseFiles(files...)
+	if err != nil {
+		return err
+	}
+
+	generated, err := gen.GenerateEntities(parsed)
+	if err != nil {
+		return err
+	}
 	totalGenerated := 0
-	for _, file := range files {
-		entities, err := generator.GenerateFromFile(file, outputDir)
-		if err != nil {
-			fmt.Printf("Warning: failed to process file %s: %v\n", file, err)
-			continue
-		}
-		// Only log if entities were generated
-		if len(entities) > 0 {
-			for _, entityName := range entities {
-				fmt.Printf("Generated entity: %s\n", entityName)
-				totalGenerated++
-			}
-		}
+	for _, entityName := range generated {
+		fmt.Printf("Generated entity: %s\n", entityName)
+		totalGenerated++
 	}
 
 	if totalGenerated > 0 {
-		fmt.Printf("\nSuccessfully generated %d entity(ies) in %s\n", totalGenerated, outputDir)
+		fmt.Printf("\nSuccessfully generated %d entity(ies) in %s\n", totalGenerated, config.OutputDir)
 	}
 	return nil
 }
@@ -112,6 +112,7 @@ generate:
   input: "./schema"             # Input directory containing schema files
   output: "gen/grizzle/schema"  # Output directory for generated code
   recursive: true               # Process subdirectories recursively
+  # flavor: "postgres"          # Quote generated identifiers for this database flavor
 
 # Optional: Define specific entities to generate
 # entities:
@@ -1,13 +1,22 @@
 package flavors
 
 import (
+	"strings"
+
 	"github.com/huandu/go-sqlbuilder"
 )
 
 // CreateTableBuilder wraps sqlbuilder.CreateTableBuilder with flavor support
 type CreateTableBuilder struct {
-	flavor  Flavor
-	builder *sqlbuilder.CreateTableBuilder
+	flavor      Flavor
+	builder     *sqlbuilder.CreateTableBuilder
+	table       string
+	temporary   bool
+	unlogged    bool
+	orReplace   bool
+	ifNotExists bool
+	defs        []string
+	options     []string
 }
 
 // NewCreateTableBuilder creates a new CreateTableBuilder for the specified flavor
@@ -21,21 +30,108 @@ func NewCreateTableBuilder(flavor Flavor) *CreateTableBuilder {
 // CreateTable sets the table name
 func (b *CreateTableBuilder) CreateTable(tableName string) *CreateTableBuilder {
 	b.builder.CreateTable(tableName)
+	b.table = tableName
+	return b
+}
+
+// CreateTempTable sets the name of a temporary table: GLOBAL TEMPORARY on Oracle and TEMP on Informix
+func (b *CreateTableBuilder) CreateTempTable(tableName string) *CreateTableBuilder {
+	b.CreateTable(tableName)
+	b.temporary = true
+	return b
+}
+
+// Unlogged creates a PostgreSQL table that is not written to the write-ahead log
+func (b *CreateTableBuilder) Unlogged() *CreateTableBuilder {
+	b.unlogged = true
+	return b
+}
+
+// OrReplace replaces an existing table of the same name
+func (b *CreateTableBuilder) OrReplace() *CreateTableBuilder {
+	b.orReplace = true
+	return b
+}
+
+// IfNotExists leaves an existing table of the same name alone. SQL Server has no IF NOT EXISTS
+// and checks OBJECT_ID instead
+func (b *CreateTableBuilder) IfNotExists() *CreateTableBuilder {
+	b.ifNotExists = true
 	return b
 }
 
 // Define adds a column definition
 func (b *CreateTableBuilder) Define(definition string) *CreateTableBuilder {
 	b.builder.Define(definition)
+	b.defs = append(b.defs, definition)
 	return b
 }
 
-// Build builds the SQL and returns the query string and arguments
+// Option adds a table option after the column definitions
+func (b *CreateTableBuilder) Option(option string) *CreateTableBuilder {
+	b.builder.Option(option)
+	b.options = append(b.options, option)
+	return b
+}
+
+// modifier returns the keyword between CREATE and TABLE, if any
+func (b *CreateTableBuilder) modifier() string {
+	switch {
+	case b.temporary && b.flavor.Base() == Oracle:
+		return "GLOBAL TEMPORARY"
+	case b.temporary && b.flavor.Base() == Informix:
+		return "TEMP"
+	case b.temporary:
+		return "TEMPORARY"
+	case b.unlogged:
+		return "UNLOGGED"
+	default:
+		return ""
+	}
+}
+
+// Build builds the SQL for the builder's flavor and returns the query string and arguments.
+// Flavors without a go-sqlbuilder counterpart, such as Informix, are rendered by the wrapper
 func (b *CreateTableBuilder) Build() (string, []interface{}) {
-	return b.builder.Build()
+	verb := "CREATE "
+	if b.orReplace {
+		verb += "OR REPLACE "
+	}
+	if modifier := b.modifier(); modifier != "" {
+		verb += modifier + " "
+	}
+	verb += "TABLE"
+	if b.ifNotExists && b.flavor.Base() != SQLServer {
+		verb += " IF NOT EXISTS"
+	}
+
+	var sql string
+	var args []interface{}
+	if flavor, ok := b.flavor.SQLBuilderFlavor(); ok {
+		sql, args = b.builder.BuildWithFlavor(flavor)
+		sql = verb + strings.TrimPrefix(sql, "CREATE TABLE")
+	} else {
+		sql = b.render(verb)
+	}
+	if b.ifNotExists && b.flavor.Base() == SQLServer {
+		sql = "IF OBJECT_ID(N'" + strings.ReplaceAll(b.table, "'", "''") + "', N'U') IS NULL " + sql
+	}
+	return sql, args
+}
+
+// render renders the statement without go-sqlbuilder
+func (b *CreateTableBuilder) render(verb string) string {
+	sql := verb + " " + b.table
+	if len(b.defs) > 0 {
+		sql += " (" + strings.Join(b.defs, ", ") + ")"
+	}
+	if len(b.options) > 0 {
+		sql += " " + strings.Join(b.options, ", ")
+	}
+	return sql
 }
 
-// SetFlavor sets the flavor (for compatibility)
+// SetFlavor sets the flavor the statement is built for
 func (b *CreateTableBuilder) SetFlavor(flavor Flavor) *CreateTableBuilder {
 	b.flavor = flavor
 	return b
@@ -0,0 +1,289 @@
+package flavors
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golshani-mhd/grizzle-kit/mapping"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// Dialect is how a database spells the SQL grizzle-kit generates. The built-in flavors implement
+// it, and Register adds other databases. A dialect can embed the Dialect of a built-in flavor
+// and override only what differs, e.g.
+//
+//	type cockroach struct{ flavors.Dialect }
+//
+//	func (cockroach) Name() string { return "cockroachdb" }
+//
+//	var CockroachDB = flavors.Register(cockroach{flavors.PostgreSQL.Dialect()})
+type Dialect interface {
+	// Name is the name the flavor is printed and parsed as, e.g. "cockroachdb"
+	Name() string
+	// Base is the built-in flavor whose DDL quirks the dialect shares, such as how comments,
+	// enum types and triggers are created
+	Base() Flavor
+	// Quote quotes an identifier
+	Quote(identifier string) string
+	// SQLBuilderFlavor returns the go-sqlbuilder flavor of the dialect, false if there is none
+	SQLBuilderFlavor() (sqlbuilder.Flavor, bool)
+	// ColumnType returns the SQL type of an abstract column type (a types.ColumnType) with its
+	// length, precision and scale, which may be nil
+	ColumnType(columnType int, length, precision, scale *int) string
+	// AutoIncrement returns the definition of an auto-incrementing integer column of the given
+	// abstract and SQL type, e.g. "INT AUTO_INCREMENT" or "SERIAL"
+	AutoIncrement(columnType int, sqlType string) string
+	// FormatDefault renders a literal default value: nil, a number, bool, string, time.Time or []byte
+	FormatDefault(value any) string
+}
+
+// registration is a registered dialect with its name, computed once outside the registry lock
+type registration struct {
+	name    string
+	dialect Dialect
+}
+
+var (
+	registryMu sync.Mutex                     // Serializes Register
+	registry   atomic.Pointer[[]registration] // Registered dialects, the first one being Flavor(firstRegistered)
+)
+
+// firstRegistered is the value of the first registered flavor; built-in flavors come before it
+const firstRegistered = 100
+
+// Register adds a dialect and returns its flavor. Registering a name twice replaces the dialect
+// under the same flavor. Names are matched case-insensitively by ParseFlavor.
+func Register(dialect Dialect) Flavor {
+	name := dialect.Name()
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	// The registry is copied so that lookups, which run for every quoted identifier, take no lock
+	registered := append([]registration{}, registrations()...)
+	for i, r := range registered {
+		if strings.EqualFold(r.name, name) {
+			registered[i].dialect = dialect
+			registry.Store(&registered)
+			return Flavor(firstRegistered + i)
+		}
+	}
+	registered = append(registered, registration{name, dialect})
+	registry.Store(&registered)
+	return Flavor(firstRegistered + len(registered) - 1)
+}
+
+// registrations returns the registered dialects
+func registrations() []registration {
+	if registered := registry.Load(); registered != nil {
+		return *registered
+	}
+	return nil
+}
+
+// lookup returns the registered dialect of a flavor
+func lookup(f Flavor) (Dialect, bool) {
+	if f < firstRegistered {
+		return nil, false
+	}
+	registered := registrations()
+	if i := int(f) - firstRegistered; i < len(registered) {
+		return registered[i].dialect, true
+	}
+	return nil, false
+}
+
+// registeredFlavors returns the registered flavors with their names
+func registeredFlavors() map[Flavor]string {
+	registered := registrations()
+	flavors := make(map[Flavor]string, len(registered))
+	for i, r := range registered {
+		flavors[Flavor(firstRegistered+i)] = r.name
+	}
+	return flavors
+}
+
+// Dialect returns the dialect of the flavor
+func (f Flavor) Dialect() Dialect {
+	if d, ok := lookup(f); ok {
+		return d
+	}
+	return builtinDialect{flavor: f}
+}
+
+// Base returns the built-in flavor the flavor follows: itself for built-in flavors
+func (f Flavor) Base() Flavor {
+	if d, ok := lookup(f); ok {
+		return d.Base()
+	}
+	return f
+}
+
+// builtinDialect is the Dialect of a built-in flavor, targeting a server version if set
+type builtinDialect struct {
+	flavor  Flavor
+	version string
+}
+
+func (d builtinDialect) Name() string {
+	if d.version != "" {
+		return d.flavor.String() + "@" + d.version
+	}
+	return d.flavor.String()
+}
+
+func (d builtinDialect) Base() Flavor    { return d.flavor }
+func (d builtinDialect) Version() string { return d.version }
+
+// Quote escapes quote characters embedded in the identifier by doubling them
+func (d builtinDialect) Quote(identifier string) string {
+	switch d.flavor {
+	case MySQL, MariaDB:
+		return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+	case PostgreSQL, SQLite, ClickHouse, Presto, Oracle, Informix, CockroachDB, DuckDB:
+		return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+	case SQLServer:
+		return "[" + strings.ReplaceAll(identifier, "]", "]]") + "]"
+	case CQL:
+		return identifier // CQL doesn't use quotes for identifiers
+	default:
+		return identifier
+	}
+}
+
+func (d builtinDialect) SQLBuilderFlavor() (sqlbuilder.Flavor, bool) {
+	switch d.flavor {
+	case MySQL:
+		return sqlbuilder.MySQL, true
+	case PostgreSQL:
+		return sqlbuilder.PostgreSQL, true
+	case SQLite:
+		return sqlbuilder.SQLite, true
+	case SQLServer:
+		return sqlbuilder.SQLServer, true
+	case CQL:
+		return sqlbuilder.CQL, true
+	case ClickHouse:
+		return sqlbuilder.ClickHouse, true
+	case Presto:
+		return sqlbuilder.Presto, true
+	case Oracle:
+		return sqlbuilder.Oracle, true
+	case MariaDB:
+		return sqlbuilder.MySQL, true // MariaDB speaks the MySQL protocol
+	case CockroachDB, DuckDB:
+		return sqlbuilder.PostgreSQL, true // Both accept PostgreSQL's $1 placeholders
+	default:
+		return 0, false
+	}
+}
+
+func (d builtinDialect) ColumnType(columnType int, length, precision, scale *int) string {
+	// MariaDB has a native UUID type from 10.7
+	if d.flavor == MariaDB && mapping.ColumnType(columnType) == mapping.ColumnTypeUuid {
+		if cmp, ok := compareVersions(d.flavor, d.version, "10.7"); ok && cmp < 0 {
+			return "CHAR(36)"
+		}
+	}
+	return mapping.SQLType(mapping.Flavor(d.flavor), mapping.ColumnType(columnType), length, precision, scale)
+}
+
+func (d builtinDialect) AutoIncrement(columnType int, sqlType string) string {
+	switch d.flavor {
+	case MySQL, MariaDB:
+		return sqlType + " AUTO_INCREMENT"
+	case PostgreSQL:
+		// Identity columns replace serial types from PostgreSQL 10
+		if cmp, ok := compareVersions(d.flavor, d.version, "10"); ok && cmp >= 0 {
+			return sqlType + " GENERATED BY DEFAULT AS IDENTITY"
+		}
+		switch mapping.ColumnType(columnType) {
+		case mapping.ColumnTypeSmallInt:
+			return "SMALLSERIAL"
+		case mapping.ColumnTypeInt:
+			return "SERIAL"
+		case mapping.ColumnTypeBigInt:
+			return "BIGSERIAL"
+		default:
+			return sqlType + " GENERATED BY DEFAULT AS IDENTITY"
+		}
+	case SQLite:
+		return "INTEGER PRIMARY KEY AUTOINCREMENT"
+	case SQLServer:
+		return sqlType + " IDENTITY(1,1)"
+	case Oracle:
+		return sqlType + " GENERATED ALWAYS AS IDENTITY"
+	case Informix:
+		switch mapping.ColumnType(columnType) {
+		case mapping.ColumnTypeInt:
+			return "SERIAL"
+		case mapping.ColumnTypeBigInt:
+			return "BIGSERIAL"
+		default:
+			return sqlType
+		}
+	case CockroachDB:
+		// unique_rowid() values need 64 bits; narrower columns use an identity, backed by a sequence
+		if mapping.ColumnType(columnType) == mapping.ColumnTypeBigInt {
+			return sqlType + " DEFAULT unique_rowid()"
+		}
+		return sqlType + " GENERATED BY DEFAULT AS IDENTITY"
+	case DuckDB:
+		return sqlType // The table creates a sequence for the column, see types.Table.BuildCreate
+	case CQL, ClickHouse, Presto:
+		panic(fmt.Sprintf("auto-increment not supported for flavor: %s", d.flavor))
+	default:
+		panic(fmt.Sprintf("unsupported flavor for auto-increment: %s", d.flavor))
+	}
+}
+
+func (d builtinDialect) FormatDefault(v any) string {
+	if v == nil {
+		return "NULL"
+	}
+	rv := reflect.ValueOf(v)
+	kind := rv.Kind()
+	if kind >= reflect.Int && kind <= reflect.Int64 {
+		return fmt.Sprint(rv.Int())
+	}
+	if kind >= reflect.Uint && kind <= reflect.Uint64 {
+		return fmt.Sprint(rv.Uint())
+	}
+	if kind == reflect.Float32 || kind == reflect.Float64 {
+		return fmt.Sprint(rv.Float())
+	}
+	if kind == reflect.Bool {
+		b := rv.Bool()
+		switch d.flavor {
+		case PostgreSQL, CQL, ClickHouse, Presto, Oracle, Informix, CockroachDB, DuckDB:
+			if b {
+				return "TRUE"
+			}
+			return "FALSE"
+		default:
+			if b {
+				return "1"
+			}
+			return "0"
+		}
+	}
+	if kind == reflect.String {
+		return "'" + strings.Replace(rv.String(), "'", "''", -1) + "'"
+	}
+	if t, ok := v.(time.Time); ok {
+		return "'" + t.Format("2006-01-02 15:04:05") + "'"
+	}
+	if b, ok := v.([]byte); ok {
+		var buf bytes.Buffer
+		buf.WriteString("X'")
+		for _, byteVal := range b {
+			fmt.Fprintf(&buf, "%02X", byteVal)
+		}
+		buf.WriteString("'")
+		return buf.String()
+	}
+	panic(fmt.Sprintf("unsupported default type: %T", v))
+}
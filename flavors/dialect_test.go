@@ -0,0 +1,37 @@
+package flavors
+
+import "testing"
+
+type testDialect struct {
+	Dialect
+	name string
+}
+
+func (d testDialect) Name() string { return d.name }
+
+func TestRegister(t *testing.T) {
+	first := Register(testDialect{PostgreSQL.Dialect(), "test-pg"})
+	again := Register(testDialect{MySQL.Dialect(), "TEST-PG"})
+	other := Register(testDialect{SQLite.Dialect(), "test-sqlite"})
+
+	tests := []struct {
+		flavor Flavor
+		base   Flavor
+		quoted string
+	}{
+		{PostgreSQL, PostgreSQL, `"a"`},
+		{first, MySQL, "`a`"},
+		{other, SQLite, `"a"`},
+	}
+	if again != first {
+		t.Errorf("registering a name twice gave flavors %d and %d", first, again)
+	}
+	for _, tt := range tests {
+		if got := tt.flavor.Base(); got != tt.base {
+			t.Errorf("%s: Base() = %s, want %s", tt.flavor, got, tt.base)
+		}
+		if got := tt.flavor.Quote("a"); got != tt.quoted {
+			t.Errorf("%s: Quote(a) = %s, want %s", tt.flavor, got, tt.quoted)
+		}
+	}
+}
@@ -20,9 +20,15 @@ const (
 	Presto
 	Oracle
 	Informix
+	MariaDB
+	CockroachDB
+	DuckDB
 )
 
 func (f Flavor) String() string {
+	if d, ok := lookup(f); ok {
+		return d.Name()
+	}
 	switch f {
 	case MySQL:
 		return "MySQL"
@@ -42,55 +48,67 @@ func (f Flavor) String() string {
 		return "Oracle"
 	case Informix:
 		return "Informix"
+	case MariaDB:
+		return "MariaDB"
+	case CockroachDB:
+		return "CockroachDB"
+	case DuckDB:
+		return "DuckDB"
 	default:
 		return "Unknown"
 	}
 }
 
-// Quote quotes an identifier for the specific database flavor
+// Quote quotes an identifier for the specific database flavor.
+// Quote characters embedded in the identifier are escaped by doubling them.
 func (f Flavor) Quote(identifier string) string {
-	switch f {
-	case MySQL:
-		return "`" + identifier + "`"
-	case PostgreSQL, SQLite, ClickHouse, Presto, Oracle, Informix:
-		return `"` + identifier + `"`
-	case SQLServer:
-		return "[" + identifier + "]"
-	case CQL:
-		return identifier // CQL doesn't use quotes for identifiers
-	default:
-		return identifier
+	return f.Dialect().Quote(identifier)
+}
+
+// QuoteQualified quotes a name qualified by its schema, such as schema.table. Only the first dot
+// separates the schema, so the name itself may contain dots.
+func (f Flavor) QuoteQualified(name string) string {
+	parts := strings.SplitN(name, ".", 2)
+	for i, part := range parts {
+		parts[i] = f.Quote(part)
 	}
+	return strings.Join(parts, ".")
+}
+
+// SQLBuilderFlavor returns the corresponding sqlbuilder.Flavor, and false for flavors go-sqlbuilder
+// does not support, such as Informix
+func (f Flavor) SQLBuilderFlavor() (sqlbuilder.Flavor, bool) {
+	return f.Dialect().SQLBuilderFlavor()
 }
 
 // GetSQLBuilderFlavor returns the corresponding sqlbuilder.Flavor
+//
+// Deprecated: flavors go-sqlbuilder does not support fall back to MySQL, whose ? placeholders
+// Informix shares but whose SQL it does not; use SQLBuilderFlavor
 func (f Flavor) GetSQLBuilderFlavor() sqlbuilder.Flavor {
-	switch f {
-	case MySQL:
-		return sqlbuilder.MySQL
-	case PostgreSQL:
-		return sqlbuilder.PostgreSQL
-	case SQLite:
-		return sqlbuilder.SQLite
-	case SQLServer:
-		return sqlbuilder.SQLServer
-	case CQL:
-		return sqlbuilder.CQL
-	case ClickHouse:
-		return sqlbuilder.ClickHouse
-	case Presto:
-		return sqlbuilder.Presto
-	case Oracle:
-		return sqlbuilder.Oracle
-	case Informix:
-		return sqlbuilder.MySQL // Fallback for Informix
-	default:
-		return sqlbuilder.MySQL // Default fallback
+	if flavor, ok := f.SQLBuilderFlavor(); ok {
+		return flavor
 	}
+	return sqlbuilder.MySQL // Fallback for Informix
 }
 
-// ParseFlavor parses a string to Flavor
+// ParseFlavor parses a string to Flavor. A version may follow an @, e.g. "postgres@15" or "mysql@8.0"
 func ParseFlavor(s string) (Flavor, error) {
+	for flavor, name := range registeredFlavors() {
+		if strings.EqualFold(name, s) {
+			return flavor, nil
+		}
+	}
+	if name, version, ok := strings.Cut(s, "@"); ok {
+		flavor, err := ParseFlavor(name)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := parseVersion(version); err != nil {
+			return 0, err
+		}
+		return flavor.WithVersion(version), nil
+	}
 	switch strings.ToLower(s) {
 	case "mysql":
 		return MySQL, nil
@@ -110,14 +128,21 @@ func ParseFlavor(s string) (Flavor, error) {
 		return Oracle, nil
 	case "informix":
 		return Informix, nil
+	case "mariadb":
+		return MariaDB, nil
+	case "cockroachdb", "cockroach", "crdb":
+		return CockroachDB, nil
+	case "duckdb":
+		return DuckDB, nil
 	default:
 		return 0, fmt.Errorf("unsupported database flavor: %s", s)
 	}
 }
 
-// GetSupportedFlavors returns a list of all supported database flavors
+// GetSupportedFlavors returns a list of all supported database flavors, registered ones last.
+// Flavors targeting a specific version are not listed
 func GetSupportedFlavors() []Flavor {
-	return []Flavor{
+	supported := []Flavor{
 		MySQL,
 		PostgreSQL,
 		SQLite,
@@ -127,5 +152,17 @@ func GetSupportedFlavors() []Flavor {
 		Presto,
 		Oracle,
 		Informix,
+		MariaDB,
+		CockroachDB,
+		DuckDB,
+	}
+	for flavor := Flavor(firstRegistered); ; flavor++ {
+		if _, ok := lookup(flavor); !ok {
+			break
+		}
+		if flavor.Version() == "" {
+			supported = append(supported, flavor)
+		}
 	}
+	return supported
 }
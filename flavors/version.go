@@ -0,0 +1,113 @@
+package flavors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sqlServerReleases maps SQL Server release years to their major versions
+var sqlServerReleases = map[int]int{2008: 10, 2012: 11, 2014: 12, 2016: 13, 2017: 14, 2019: 15, 2022: 16, 2025: 17}
+
+// versionedDialect targets a server version of a registered dialect
+type versionedDialect struct {
+	Dialect
+	version string
+}
+
+func (d versionedDialect) Name() string    { return d.Dialect.Name() + "@" + d.version }
+func (d versionedDialect) Version() string { return d.version }
+
+// WithVersion returns the flavor targeting the given server version, e.g. PostgreSQL.WithVersion("15")
+// or SQLServer.WithVersion("2016"), so that DDL uses the syntax that version supports. It panics
+// if the version is not made of dot-separated numbers.
+func (f Flavor) WithVersion(version string) Flavor {
+	if _, err := parseVersion(version); err != nil {
+		panic(err.Error())
+	}
+	switch d := f.Dialect().(type) {
+	case builtinDialect:
+		d.version = version
+		return Register(d)
+	case versionedDialect:
+		d.version = version
+		return Register(d)
+	default:
+		return Register(versionedDialect{d, version})
+	}
+}
+
+// Version returns the server version the flavor targets, "" when it targets none
+func (f Flavor) Version() string {
+	if d, ok := f.Dialect().(interface{ Version() string }); ok {
+		return d.Version()
+	}
+	return ""
+}
+
+// AtLeast reports whether the flavor targets the given version or a later one. It is false for
+// flavors without a version, whose DDL does not depend on it.
+func (f Flavor) AtLeast(version string) bool {
+	cmp, ok := compareVersions(f.Base(), f.Version(), version)
+	return ok && cmp >= 0
+}
+
+// Before reports whether the flavor targets a version older than the given one. It is false for
+// flavors without a version, whose DDL does not depend on it.
+func (f Flavor) Before(version string) bool {
+	cmp, ok := compareVersions(f.Base(), f.Version(), version)
+	return ok && cmp < 0
+}
+
+// parseVersion splits a version such as "8.0.16" into its numbers
+func parseVersion(version string) ([]int, error) {
+	var numbers []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid database version: %q", version)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+// compareVersions compares two versions of a flavor, missing numbers counting as zero, and
+// reports false if either is empty. SQL Server versions may be given as release years.
+func compareVersions(flavor Flavor, a, b string) (int, bool) {
+	if a == "" || b == "" {
+		return 0, false
+	}
+	x, err := parseVersion(a)
+	if err != nil {
+		return 0, false
+	}
+	y, err := parseVersion(b)
+	if err != nil {
+		return 0, false
+	}
+	if flavor == SQLServer {
+		if major, ok := sqlServerReleases[x[0]]; ok {
+			x[0] = major
+		}
+		if major, ok := sqlServerReleases[y[0]]; ok {
+			y[0] = major
+		}
+	}
+	for i := 0; i < len(x) || i < len(y); i++ {
+		var m, n int
+		if i < len(x) {
+			m = x[i]
+		}
+		if i < len(y) {
+			n = y[i]
+		}
+		if m != n {
+			if m < n {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
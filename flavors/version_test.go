@@ -0,0 +1,29 @@
+package flavors
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		flavor Flavor
+		a, b   string
+		cmp    int
+		ok     bool
+	}{
+		{PostgreSQL, "", "15", 0, false},
+		{PostgreSQL, "15", "", 0, false},
+		{PostgreSQL, "15", "x", 0, false},
+		{PostgreSQL, "15", "15.0", 0, true},
+		{MySQL, "8.0.16", "8.0.13", 1, true},
+		{MySQL, "5.7", "8.0", -1, true},
+		{SQLServer, "2019", "15", 0, true},
+		{SQLServer, "2016", "2017", -1, true},
+		{SQLServer, "16", "2019", 1, true},
+		{PostgreSQL, "2019", "15", 1, true},
+	}
+	for _, tt := range tests {
+		cmp, ok := compareVersions(tt.flavor, tt.a, tt.b)
+		if cmp != tt.cmp || ok != tt.ok {
+			t.Errorf("compareVersions(%s, %q, %q) = %d, %t; want %d, %t", tt.flavor, tt.a, tt.b, cmp, ok, tt.cmp, tt.ok)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package generator
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 
@@ -10,48 +9,80 @@ import (
 
 // GenerateFromTable generates entity files from a table definition
 func GenerateFromTable(table *types.Table, entityName, outputDir string) error {
-	entity := &EntityInfo{
-		Name:    entityName,
-		Table:   table,
-		Columns: analyzeTableColumns(table),
-	}
-	config := &GeneratorConfig{OutputDir: outputDir}
-	gen := NewGenerator(config)
-	return gen.generateEntityFile(*entity)
+	return GenerateFromTables(map[string]*types.Table{entityName: table}, outputDir)
 }
 
 // GenerateFromTables generates entity files from multiple table definitions
 func GenerateFromTables(tables map[string]*types.Table, outputDir string) error {
-	config := &GeneratorConfig{OutputDir: outputDir}
-	gen := NewGenerator(config)
+	_, err := NewGenerator(&GeneratorConfig{OutputDir: outputDir}).GenerateTables(tables)
+	return err
+}
+
+// GenerateTables generates entity files from table definitions keyed by entity name, with the
+// generator's configuration such as its flavor and type overrides
+// Returns the list of generated entity names
+func (g *Generator) GenerateTables(tables map[string]*types.Table) ([]string, error) {
+	var entities []EntityInfo
 	for entityName, table := range tables {
-		entity := &EntityInfo{
+		entities = append(entities, EntityInfo{
 			Name:    entityName,
 			Table:   table,
-			Columns: analyzeTableColumns(table),
-		}
-		if err := gen.generateEntityFile(*entity); err != nil {
-			return fmt.Errorf("failed to generate entity %s: %w", entityName, err)
-		}
+			Columns: g.analyzeTableColumns(table),
+		})
 	}
-	return nil
+	return g.GenerateEntities(entities)
 }
 
 // analyzeTableColumns analyzes table columns and extracts type information
-func analyzeTableColumns(table *types.Table) []ColumnInfo {
+func (g *Generator) analyzeTableColumns(table *types.Table) []ColumnInfo {
 	var columns []ColumnInfo
 	for _, col := range table.Columns {
+		goType := col.GoType
+		if goType == "" {
+			switch col.AbstractType {
+			case types.ColumnTypePostgresArray, types.ColumnTypeCQLList, types.ColumnTypeCQLSet:
+				goType = "[]" + getGoTypeFromColumnType(col.ElementType)
+			case types.ColumnTypeMySQLSet:
+				goType = "[]string"
+			case types.ColumnTypeCQLMap:
+				goType = "map[" + getGoTypeFromColumnType(col.KeyType) + "]" + getGoTypeFromColumnType(col.ElementType)
+			default:
+				goType = getGoTypeFromColumnType(col.AbstractType)
+			}
+		}
+		abstractType := columnTypeName(col.AbstractType)
+		if override, ok := g.typeOverride(abstractType); ok && col.GoType == "" {
+			goType = override
+		}
 		columnInfo := ColumnInfo{
-			Name:          col.Name,
-			GoType:        getGoTypeFromColumnType(col.AbstractType),
-			SQLType:       col.AbstractType.String(),
-			AbstractType:  col.AbstractType.String(),
-			AutoIncrement: col.AutoIncrement,
-			HasDefault:    col.HasDefault,
-			DefaultValue:  col.Default,
-			Length:        col.Length,
-			Precision:     col.Precision,
-			Scale:         col.Scale,
+			Name:           col.Name,
+			GoType:         goType,
+			SQLType:        col.Type,
+			AbstractType:   abstractType,
+			AutoIncrement:  col.AutoIncrement,
+			HasDefault:     col.HasDefault,
+			DefaultValue:   col.Default,
+			DefaultExpr:    col.DefaultExpr,
+			Length:         col.Length,
+			Precision:      col.Precision,
+			Scale:          col.Scale,
+			References:     col.References,
+			EnumValues:     col.EnumValues,
+			Check:          col.Check,
+			Generated:      col.Generated,
+			OnUpdateNow:    col.OnUpdateNow,
+			Comment:        col.Comment,
+			LowCardinality: col.LowCardinality,
+			Nullable:       col.Nullable,
+			Domain:         col.Domain,
+		}
+		switch col.AbstractType {
+		case types.ColumnTypePostgresArray, types.ColumnTypeCQLList, types.ColumnTypeCQLSet:
+			columnInfo.ElementType = columnTypeName(col.ElementType)
+		case types.ColumnTypeMySQLSet:
+			columnInfo.ElementType = "ColumnTypeText"
+		case types.ColumnTypeCQLMap:
+			columnInfo.KeyType, columnInfo.ElementType = columnTypeName(col.KeyType), columnTypeName(col.ElementType)
 		}
 		columns = append(columns, columnInfo)
 	}
@@ -85,8 +116,10 @@ func getGoTypeFromColumnType(columnType types.ColumnType) string {
 		return "[]byte"
 	case types.ColumnTypeJson, types.ColumnTypeUuid, types.ColumnTypeXml:
 		return "string"
-	case types.ColumnTypeBit:
+	case types.ColumnTypeBit, types.ColumnTypeCQLCounter:
 		return "int64"
+	case types.ColumnTypeMySQLEnum:
+		return "string"
 	default:
 		return "interface{}"
 	}
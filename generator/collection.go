@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// collectionScanner returns the model field type of a collection column for the configured flavor:
+// types.PGArray on PostgreSQL and CockroachDB, types.MySQLSet for MySQL and MariaDB SET columns and JSON-backed types where
+// collections are stored as JSON. It returns nil to keep the plain []T or map[K]V when no flavor
+// is configured or the driver scans collections natively.
+func (g *Generator) collectionScanner(col ColumnInfo) *jen.Statement {
+	if col.ElementType == "" {
+		return nil
+	}
+	flavor, ok := g.flavor()
+	if !ok {
+		return nil
+	}
+	switch flavor.Base() {
+	case flavors.CQL, flavors.ClickHouse, flavors.Presto, flavors.Informix, flavors.DuckDB:
+		return nil
+	}
+	if key, value, ok := splitMapType(col.GoType); ok {
+		return jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "JSONMap").Types(g.goTypeCode(key), g.goTypeCode(value))
+	}
+	elem := strings.TrimPrefix(col.GoType, "[]")
+	switch {
+	case flavor.Base() == flavors.PostgreSQL, flavor.Base() == flavors.CockroachDB:
+		return jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "PGArray").Types(g.goTypeCode(elem))
+	case (flavor.Base() == flavors.MySQL || flavor.Base() == flavors.MariaDB) && col.AbstractType == "ColumnTypeMySQLSet":
+		return jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "MySQLSet")
+	default:
+		return jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "JSONArray").Types(g.goTypeCode(elem))
+	}
+}
+
+// splitMapType splits a map[K]V Go type into its key and value types.
+func splitMapType(goType string) (key, value string, ok bool) {
+	if !strings.HasPrefix(goType, "map[") {
+		return "", "", false
+	}
+	depth := 0
+	for i := len("map"); i < len(goType); i++ {
+		switch goType[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return goType[len("map["):i], goType[i+1:], true
+			}
+		}
+	}
+	return "", "", false
+}
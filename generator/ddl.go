@@ -0,0 +1,354 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+	"github.com/golshani-mhd/grizzle-kit/types"
+)
+
+// columnTypes maps the names of the types constants to their column types
+var columnTypes = map[string]types.ColumnType{
+	"ColumnTypeVarchar":                           types.ColumnTypeVarchar,
+	"ColumnTypeChar":                              types.ColumnTypeChar,
+	"ColumnTypeText":                              types.ColumnTypeText,
+	"ColumnTypeTinyInt":                           types.ColumnTypeTinyInt,
+	"ColumnTypeSmallInt":                          types.ColumnTypeSmallInt,
+	"ColumnTypeInt":                               types.ColumnTypeInt,
+	"ColumnTypeBigInt":                            types.ColumnTypeBigInt,
+	"ColumnTypeBoolean":                           types.ColumnTypeBoolean,
+	"ColumnTypeReal":                              types.ColumnTypeReal,
+	"ColumnTypeDouble":                            types.ColumnTypeDouble,
+	"ColumnTypeDecimal":                           types.ColumnTypeDecimal,
+	"ColumnTypeDate":                              types.ColumnTypeDate,
+	"ColumnTypeTime":                              types.ColumnTypeTime,
+	"ColumnTypeDateTime":                          types.ColumnTypeDateTime,
+	"ColumnTypeTimestamp":                         types.ColumnTypeTimestamp,
+	"ColumnTypeBlob":                              types.ColumnTypeBlob,
+	"ColumnTypeJson":                              types.ColumnTypeJson,
+	"ColumnTypeUuid":                              types.ColumnTypeUuid,
+	"ColumnTypeBit":                               types.ColumnTypeBit,
+	"ColumnTypeBinary":                            types.ColumnTypeBinary,
+	"ColumnTypeVarbinary":                         types.ColumnTypeVarbinary,
+	"ColumnTypeMoney":                             types.ColumnTypeMoney,
+	"ColumnTypeXml":                               types.ColumnTypeXml,
+	"ColumnTypePostgresJsonb":                     types.ColumnTypePostgresJsonb,
+	"ColumnTypePostgresHstore":                    types.ColumnTypePostgresHstore,
+	"ColumnTypePostgresTsVector":                  types.ColumnTypePostgresTsVector,
+	"ColumnTypePostgresMoney":                     types.ColumnTypePostgresMoney,
+	"ColumnTypePostgresInterval":                  types.ColumnTypePostgresInterval,
+	"ColumnTypePostgresInet":                      types.ColumnTypePostgresInet,
+	"ColumnTypePostgresMacaddr":                   types.ColumnTypePostgresMacaddr,
+	"ColumnTypePostgresMacaddr8":                  types.ColumnTypePostgresMacaddr8,
+	"ColumnTypePostgresBit":                       types.ColumnTypePostgresBit,
+	"ColumnTypePostgresVarbit":                    types.ColumnTypePostgresVarbit,
+	"ColumnTypePostgresBox":                       types.ColumnTypePostgresBox,
+	"ColumnTypePostgresCircle":                    types.ColumnTypePostgresCircle,
+	"ColumnTypePostgresLine":                      types.ColumnTypePostgresLine,
+	"ColumnTypePostgresLseg":                      types.ColumnTypePostgresLseg,
+	"ColumnTypePostgresPath":                      types.ColumnTypePostgresPath,
+	"ColumnTypePostgresPolygon":                   types.ColumnTypePostgresPolygon,
+	"ColumnTypePostgresTsquery":                   types.ColumnTypePostgresTsquery,
+	"ColumnTypePostgresJsonpath":                  types.ColumnTypePostgresJsonpath,
+	"ColumnTypePostgresXml":                       types.ColumnTypePostgresXml,
+	"ColumnTypePostgresArray":                     types.ColumnTypePostgresArray,
+	"ColumnTypePostgresRange":                     types.ColumnTypePostgresRange,
+	"ColumnTypePostgresMultirange":                types.ColumnTypePostgresMultirange,
+	"ColumnTypePostgresPgLsn":                     types.ColumnTypePostgresPgLsn,
+	"ColumnTypePostgresPgSnapshot":                types.ColumnTypePostgresPgSnapshot,
+	"ColumnTypeMySQLSet":                          types.ColumnTypeMySQLSet,
+	"ColumnTypeMySQLEnum":                         types.ColumnTypeMySQLEnum,
+	"ColumnTypeMySQLPoint":                        types.ColumnTypeMySQLPoint,
+	"ColumnTypeMySQLTinytext":                     types.ColumnTypeMySQLTinytext,
+	"ColumnTypeMySQLMediumtext":                   types.ColumnTypeMySQLMediumtext,
+	"ColumnTypeMySQLLongtext":                     types.ColumnTypeMySQLLongtext,
+	"ColumnTypeMySQLTinyblob":                     types.ColumnTypeMySQLTinyblob,
+	"ColumnTypeMySQLMediumblob":                   types.ColumnTypeMySQLMediumblob,
+	"ColumnTypeMySQLLongblob":                     types.ColumnTypeMySQLLongblob,
+	"ColumnTypeMySQLYear":                         types.ColumnTypeMySQLYear,
+	"ColumnTypeMySQLGeometry":                     types.ColumnTypeMySQLGeometry,
+	"ColumnTypeMySQLLinestring":                   types.ColumnTypeMySQLLinestring,
+	"ColumnTypeMySQLPolygon":                      types.ColumnTypeMySQLPolygon,
+	"ColumnTypeMySQLMultipoint":                   types.ColumnTypeMySQLMultipoint,
+	"ColumnTypeMySQLMultilinestring":              types.ColumnTypeMySQLMultilinestring,
+	"ColumnTypeMySQLMultipolygon":                 types.ColumnTypeMySQLMultipolygon,
+	"ColumnTypeMySQLGeometrycollection":           types.ColumnTypeMySQLGeometrycollection,
+	"ColumnTypeSQLServerXml":                      types.ColumnTypeSQLServerXml,
+	"ColumnTypeSQLServerGeography":                types.ColumnTypeSQLServerGeography,
+	"ColumnTypeSQLServerGeometry":                 types.ColumnTypeSQLServerGeometry,
+	"ColumnTypeSQLServerHierarchyid":              types.ColumnTypeSQLServerHierarchyid,
+	"ColumnTypeSQLServerUniqueidentifier":         types.ColumnTypeSQLServerUniqueidentifier,
+	"ColumnTypeSQLServerImage":                    types.ColumnTypeSQLServerImage,
+	"ColumnTypeSQLServerNtext":                    types.ColumnTypeSQLServerNtext,
+	"ColumnTypeSQLServerSqlVariant":               types.ColumnTypeSQLServerSqlVariant,
+	"ColumnTypeSQLServerTimestamp":                types.ColumnTypeSQLServerTimestamp,
+	"ColumnTypeSQLServerMoney":                    types.ColumnTypeSQLServerMoney,
+	"ColumnTypeSQLServerSmallmoney":               types.ColumnTypeSQLServerSmallmoney,
+	"ColumnTypeSQLServerDatetime2":                types.ColumnTypeSQLServerDatetime2,
+	"ColumnTypeSQLServerDatetimeoffset":           types.ColumnTypeSQLServerDatetimeoffset,
+	"ColumnTypeSQLServerSmalldatetime":            types.ColumnTypeSQLServerSmalldatetime,
+	"ColumnTypeCQLCounter":                        types.ColumnTypeCQLCounter,
+	"ColumnTypeCQLDuration":                       types.ColumnTypeCQLDuration,
+	"ColumnTypeCQLInet":                           types.ColumnTypeCQLInet,
+	"ColumnTypeCQLList":                           types.ColumnTypeCQLList,
+	"ColumnTypeCQLMap":                            types.ColumnTypeCQLMap,
+	"ColumnTypeCQLSet":                            types.ColumnTypeCQLSet,
+	"ColumnTypeCQLTuple":                          types.ColumnTypeCQLTuple,
+	"ColumnTypeCQLVector":                         types.ColumnTypeCQLVector,
+	"ColumnTypeClickHouseLowCardinality":          types.ColumnTypeClickHouseLowCardinality,
+	"ColumnTypeClickHouseNullable":                types.ColumnTypeClickHouseNullable,
+	"ColumnTypeClickHouseArray":                   types.ColumnTypeClickHouseArray,
+	"ColumnTypeClickHouseMap":                     types.ColumnTypeClickHouseMap,
+	"ColumnTypeClickHouseTuple":                   types.ColumnTypeClickHouseTuple,
+	"ColumnTypeClickHouseNested":                  types.ColumnTypeClickHouseNested,
+	"ColumnTypeClickHouseEnum8":                   types.ColumnTypeClickHouseEnum8,
+	"ColumnTypeClickHouseEnum16":                  types.ColumnTypeClickHouseEnum16,
+	"ColumnTypeClickHouseDate32":                  types.ColumnTypeClickHouseDate32,
+	"ColumnTypeClickHouseDateTime64":              types.ColumnTypeClickHouseDateTime64,
+	"ColumnTypeClickHouseIPv4":                    types.ColumnTypeClickHouseIPv4,
+	"ColumnTypeClickHouseIPv6":                    types.ColumnTypeClickHouseIPv6,
+	"ColumnTypeClickHouseObjectJson":              types.ColumnTypeClickHouseObjectJson,
+	"ColumnTypeClickHouseDecimal32":               types.ColumnTypeClickHouseDecimal32,
+	"ColumnTypeClickHouseDecimal64":               types.ColumnTypeClickHouseDecimal64,
+	"ColumnTypeClickHouseDecimal128":              types.ColumnTypeClickHouseDecimal128,
+	"ColumnTypeClickHouseDecimal256":              types.ColumnTypeClickHouseDecimal256,
+	"ColumnTypeClickHouseAggregateFunction":       types.ColumnTypeClickHouseAggregateFunction,
+	"ColumnTypeClickHouseSimpleAggregateFunction": types.ColumnTypeClickHouseSimpleAggregateFunction,
+	"ColumnTypePrestoRow":                         types.ColumnTypePrestoRow,
+	"ColumnTypePrestoArray":                       types.ColumnTypePrestoArray,
+	"ColumnTypePrestoMap":                         types.ColumnTypePrestoMap,
+	"ColumnTypePrestoIntervalYearToMonth":         types.ColumnTypePrestoIntervalYearToMonth,
+	"ColumnTypePrestoIntervalDayToSecond":         types.ColumnTypePrestoIntervalDayToSecond,
+	"ColumnTypePrestoIpaddress":                   types.ColumnTypePrestoIpaddress,
+	"ColumnTypePrestoGeometry":                    types.ColumnTypePrestoGeometry,
+	"ColumnTypePrestoBingTile":                    types.ColumnTypePrestoBingTile,
+	"ColumnTypePrestoHyperloglog":                 types.ColumnTypePrestoHyperloglog,
+	"ColumnTypePrestoP4hyperloglog":               types.ColumnTypePrestoP4hyperloglog,
+	"ColumnTypePrestoQdigest":                     types.ColumnTypePrestoQdigest,
+	"ColumnTypePrestoTdigest":                     types.ColumnTypePrestoTdigest,
+	"ColumnTypePrestoBarcode":                     types.ColumnTypePrestoBarcode,
+	"ColumnTypePrestoTimeWithTimezone":            types.ColumnTypePrestoTimeWithTimezone,
+	"ColumnTypePrestoTimestampWithTimezone":       types.ColumnTypePrestoTimestampWithTimezone,
+	"ColumnTypeOracleNclob":                       types.ColumnTypeOracleNclob,
+	"ColumnTypeOracleRaw":                         types.ColumnTypeOracleRaw,
+	"ColumnTypeOracleBinaryFloat":                 types.ColumnTypeOracleBinaryFloat,
+	"ColumnTypeOracleBinaryDouble":                types.ColumnTypeOracleBinaryDouble,
+	"ColumnTypeOracleIntervalYearToMonth":         types.ColumnTypeOracleIntervalYearToMonth,
+	"ColumnTypeOracleIntervalDayToSecond":         types.ColumnTypeOracleIntervalDayToSecond,
+	"ColumnTypeOracleUrowid":                      types.ColumnTypeOracleUrowid,
+	"ColumnTypeOracleAnydata":                     types.ColumnTypeOracleAnydata,
+	"ColumnTypeOracleAnytype":                     types.ColumnTypeOracleAnytype,
+	"ColumnTypeOracleAnydataset":                  types.ColumnTypeOracleAnydataset,
+	"ColumnTypeOracleXmltype":                     types.ColumnTypeOracleXmltype,
+	"ColumnTypeOracleUritype":                     types.ColumnTypeOracleUritype,
+	"ColumnTypeOracleDburitype":                   types.ColumnTypeOracleDburitype,
+	"ColumnTypeOracleXdburitype":                  types.ColumnTypeOracleXdburitype,
+	"ColumnTypeOracleHttpuritype":                 types.ColumnTypeOracleHttpuritype,
+	"ColumnTypeOracleSdoGeometry":                 types.ColumnTypeOracleSdoGeometry,
+	"ColumnTypeOracleSdoTopoGeometry":             types.ColumnTypeOracleSdoTopoGeometry,
+	"ColumnTypeOracleSdoGeoraster":                types.ColumnTypeOracleSdoGeoraster,
+	"ColumnTypeInformixLvarchar":                  types.ColumnTypeInformixLvarchar,
+	"ColumnTypeInformixByte":                      types.ColumnTypeInformixByte,
+	"ColumnTypeInformixMoney":                     types.ColumnTypeInformixMoney,
+	"ColumnTypeInformixSerial":                    types.ColumnTypeInformixSerial,
+	"ColumnTypeInformixSerial8":                   types.ColumnTypeInformixSerial8,
+	"ColumnTypeInformixBigserial":                 types.ColumnTypeInformixBigserial,
+	"ColumnTypeInformixClob":                      types.ColumnTypeInformixClob,
+	"ColumnTypeInformixInterval":                  types.ColumnTypeInformixInterval,
+	"ColumnTypeInformixList":                      types.ColumnTypeInformixList,
+	"ColumnTypeInformixMultiset":                  types.ColumnTypeInformixMultiset,
+	"ColumnTypeInformixSet":                       types.ColumnTypeInformixSet,
+	"ColumnTypeInformixRow":                       types.ColumnTypeInformixRow,
+	"ColumnTypeMariaDBInet4":                      types.ColumnTypeMariaDBInet4,
+	"ColumnTypeMariaDBInet6":                      types.ColumnTypeMariaDBInet6,
+	"ColumnTypeMariaDBUuid":                       types.ColumnTypeMariaDBUuid,
+	"ColumnTypeMariaDBVector":                     types.ColumnTypeMariaDBVector,
+	"ColumnTypeCockroachDBString":                 types.ColumnTypeCockroachDBString,
+	"ColumnTypeCockroachDBBytes":                  types.ColumnTypeCockroachDBBytes,
+	"ColumnTypeCockroachDBInt2":                   types.ColumnTypeCockroachDBInt2,
+	"ColumnTypeCockroachDBInt4":                   types.ColumnTypeCockroachDBInt4,
+	"ColumnTypeCockroachDBInt8":                   types.ColumnTypeCockroachDBInt8,
+	"ColumnTypeCockroachDBFloat4":                 types.ColumnTypeCockroachDBFloat4,
+	"ColumnTypeCockroachDBFloat8":                 types.ColumnTypeCockroachDBFloat8,
+	"ColumnTypeCockroachDBInet":                   types.ColumnTypeCockroachDBInet,
+	"ColumnTypeCockroachDBInterval":               types.ColumnTypeCockroachDBInterval,
+	"ColumnTypeCockroachDBGeography":              types.ColumnTypeCockroachDBGeography,
+	"ColumnTypeCockroachDBGeometry":               types.ColumnTypeCockroachDBGeometry,
+	"ColumnTypeCockroachDBBox2D":                  types.ColumnTypeCockroachDBBox2D,
+	"ColumnTypeCockroachDBOid":                    types.ColumnTypeCockroachDBOid,
+	"ColumnTypeDuckDBHugeInt":                     types.ColumnTypeDuckDBHugeInt,
+	"ColumnTypeDuckDBUHugeInt":                    types.ColumnTypeDuckDBUHugeInt,
+	"ColumnTypeDuckDBUTinyInt":                    types.ColumnTypeDuckDBUTinyInt,
+	"ColumnTypeDuckDBUSmallInt":                   types.ColumnTypeDuckDBUSmallInt,
+	"ColumnTypeDuckDBUInteger":                    types.ColumnTypeDuckDBUInteger,
+	"ColumnTypeDuckDBUBigInt":                     types.ColumnTypeDuckDBUBigInt,
+	"ColumnTypeDuckDBList":                        types.ColumnTypeDuckDBList,
+	"ColumnTypeDuckDBStruct":                      types.ColumnTypeDuckDBStruct,
+	"ColumnTypeDuckDBMap":                         types.ColumnTypeDuckDBMap,
+	"ColumnTypeDuckDBUnion":                       types.ColumnTypeDuckDBUnion,
+	"ColumnTypeDuckDBInterval":                    types.ColumnTypeDuckDBInterval,
+	"ColumnTypeDuckDBBitString":                   types.ColumnTypeDuckDBBitString,
+	"ColumnTypeDuckDBTimestampNs":                 types.ColumnTypeDuckDBTimestampNs,
+	"ColumnTypeDuckDBVarint":                      types.ColumnTypeDuckDBVarint,
+}
+
+// columnTypeName returns the name of the types constant of a column type, e.g. ColumnTypeVarchar
+func columnTypeName(columnType types.ColumnType) string {
+	for name, ct := range columnTypes {
+		if ct == columnType {
+			return name
+		}
+	}
+	return columnType.String()
+}
+
+// errUnknownType reports a column built by a function the generator does not know, whose type is unknown
+var errUnknownType = errors.New("unknown column type")
+
+// buildTable rebuilds the table definition of an entity from its parsed columns
+func (g *Generator) buildTable(entity EntityInfo) (*types.Table, error) {
+	table := *entity.Table
+	table.Columns = nil
+	for _, col := range entity.Columns {
+		abstractType, ok := columnTypes[col.AbstractType]
+		if !ok {
+			return nil, fmt.Errorf("column %s: %w", col.Name, errUnknownType)
+		}
+		if col.UnresolvedType != "" {
+			return nil, fmt.Errorf("type %s of column %s is neither a types constant nor a string constant of the schema files", col.UnresolvedType, col.Name)
+		}
+		column := &types.Column[any]{
+			ParentAlias:    entity.Table.Name,
+			Name:           col.Name,
+			Type:           col.SQLType,
+			AbstractType:   abstractType,
+			DefaultExpr:    col.DefaultExpr,
+			AutoIncrement:  col.AutoIncrement,
+			Length:         col.Length,
+			Precision:      col.Precision,
+			Scale:          col.Scale,
+			References:     col.References,
+			EnumValues:     col.EnumValues,
+			Check:          col.Check,
+			Generated:      col.Generated,
+			OnUpdateNow:    col.OnUpdateNow,
+			Comment:        col.Comment,
+			LowCardinality: col.LowCardinality,
+			Nullable:       col.Nullable,
+			ElementType:    columnTypes[col.ElementType],
+			KeyType:        columnTypes[col.KeyType],
+			Domain:         col.Domain,
+		}
+		if col.HasDefault {
+			if src, ok := col.DefaultValue.(unresolvedDefault); ok {
+				return nil, fmt.Errorf("default value %s of column %s cannot be written as SQL", src, col.Name)
+			}
+			column.HasDefault = true
+			column.Default = col.DefaultValue
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	return &table, nil
+}
+
+// createSQL builds the CREATE statements of the entity's table for the flavor.
+// Tables the flavor cannot create, such as ones using sequences on MySQL, are reported as errors.
+func (g *Generator) createSQL(entity EntityInfo, flavor flavors.Flavor) (sql string, err error) {
+	table, err := g.buildTable(entity)
+	if err != nil {
+		return "", err
+	}
+	return buildDDL("table "+entity.Table.Name, flavor, func() string { return table.BuildCreate(flavor) })
+}
+
+// buildDDL runs build, turning a panic about a feature the flavor lacks into an error
+func buildDDL(what string, flavor flavors.Flavor, build func() string) (sql string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot create %s for %s: %v", what, flavor, r)
+		}
+	}()
+	return build(), nil
+}
+
+// writeSQL writes the CREATE statements of the entities for the configured flavor to SQLOut: a
+// single file holding the whole schema when SQLOut ends in .sql, or one <table>.sql file per table
+// in the SQLOut directory otherwise, with the extensions, sequences and domains in _objects.sql
+func (g *Generator) writeSQL(entities []EntityInfo) error {
+	flavor, ok := g.flavor()
+	if !ok {
+		return fmt.Errorf("a flavor is required to write SQL to %s", g.config.SQLOut)
+	}
+	const header = "-- Code generated by grizzle-kit. DO NOT EDIT.\n\n"
+
+	if strings.HasSuffix(g.config.SQLOut, ".sql") {
+		objects := append([]types.SchemaObject{}, g.objects...)
+		for _, entity := range entities {
+			table, err := g.buildTable(entity)
+			if err != nil {
+				return fmt.Errorf("failed to build table %s: %w", entity.Table.Name, err)
+			}
+			objects = append(objects, table)
+		}
+		sql, err := buildDDL("schema", flavor, func() string { return types.BuildCreateAll(flavor, objects...) })
+		if err != nil {
+			return err
+		}
+		return writeSQLFile(g.config.SQLOut, header+sql+";\n")
+	}
+
+	if len(g.objects) > 0 {
+		sql, err := buildDDL("objects", flavor, func() string { return types.BuildCreateAll(flavor, g.objects...) })
+		if err != nil {
+			return err
+		}
+		if sql != "" {
+			if err := writeSQLFile(filepath.Join(g.config.SQLOut, "_objects.sql"), header+sql+";\n"); err != nil {
+				return err
+			}
+		}
+	}
+	for _, entity := range entities {
+		sql, err := g.createSQL(entity, flavor)
+		if err != nil {
+			return err
+		}
+		if err := writeSQLFile(filepath.Join(g.config.SQLOut, entity.Table.QualifiedName()+".sql"), header+sql+";\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSQLFile writes an SQL file, creating its directory if needed
+func writeSQLFile(path, sql string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create SQL output directory %s: %w", filepath.Dir(path), err)
+	}
+	return os.WriteFile(path, []byte(sql), 0644)
+}
+
+// generateCreateSQL renders the CreateSQL constant for the configured flavor, or nothing when none is set.
+func (g *Generator) generateCreateSQL(entity EntityInfo) (jen.Code, error) {
+	flavor, ok := g.flavor()
+	if !ok {
+		return nil, nil
+	}
+	sql, err := g.createSQL(entity, flavor)
+	if errors.Is(err, errUnknownType) {
+		if types.WarningHandler != nil {
+			types.WarningHandler(fmt.Sprintf("CreateSQL of table %s left out: %v", entity.Table.Name, err))
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jen.Comment(fmt.Sprintf("CreateSQL creates the %s table on %s, as built by Table.BuildCreate.", entity.Table.Name, flavor)).Line().
+		Const().Id("CreateSQL").Op("=").Lit(sql), nil
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golshani-mhd/grizzle-kit/types"
+)
+
+const typesSchema = `package schema
+
+import "github.com/golshani-mhd/grizzle-kit/types"
+
+const emailType = "CITEXT"
+
+var AccountSchema = types.Table{
+	Name: "accounts",
+	Columns: []*types.Column[any]{
+		types.BigInt("id", types.WithType[int64](types.ColumnTypeDuckDBHugeInt)),
+		types.Text("name", types.WithType[string]("CITEXT")),
+		types.Text("email", types.WithType[string](emailType)),
+		types.Text("note"),
+	},
+}
+`
+
+func TestCreateSQLTypeOverrides(t *testing.T) {
+	dir := t.TempDir()
+	schemaFile := filepath.Join(dir, "schema.go")
+	if err := os.WriteFile(schemaFile, []byte(typesSchema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gen := NewGenerator(&GeneratorConfig{OutputDir: filepath.Join(dir, "gen"), Flavor: "duckdb"})
+	entities, err := gen.ParseFiles(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flavor, _ := gen.flavor()
+	sql, err := gen.createSQL(entities[0], flavor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"id" HUGEINT`, `"name" CITEXT`, `"email" CITEXT`, `"note" VARCHAR`} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("CreateSQL lacks %s:\n%s", want, sql)
+		}
+	}
+}
+
+func TestCreateSQLUnresolvedTypes(t *testing.T) {
+	handler := types.WarningHandler
+	defer func() { types.WarningHandler = handler }()
+	types.WarningHandler = nil
+
+	tests := []struct {
+		name   string
+		column string
+		err    string // Expected error, empty when CreateSQL is left out instead
+	}{
+		{"unknown constructor", `custom("id")`, ""},
+		{"unresolved override", `types.Text("id", types.WithType[string](typeOf("id")))`, "typeOf(\"id\")"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			schemaFile := filepath.Join(dir, "schema.go")
+			schema := "package schema\n\nimport \"github.com/golshani-mhd/grizzle-kit/types\"\n\n" +
+				"var AccountSchema = types.Table{\n\tName: \"accounts\",\n\tColumns: []*types.Column[any]{\n\t\t" + tt.column + ",\n\t},\n}\n"
+			if err := os.WriteFile(schemaFile, []byte(schema), 0644); err != nil {
+				t.Fatal(err)
+			}
+			gen := NewGenerator(&GeneratorConfig{OutputDir: filepath.Join(dir, "gen"), Flavor: "postgres"})
+			entities, err := gen.ParseFiles(schemaFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			code, err := gen.generateCreateSQL(entities[0])
+			switch {
+			case tt.err == "" && (err != nil || code != nil):
+				t.Errorf("CreateSQL not left out: %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("error %v, want one naming %s", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+)
+
+// isEnumColumn reports whether the column is an enum that gets a generated Go type in the model package.
+func (g *Generator) isEnumColumn(col ColumnInfo) bool {
+	return len(col.EnumValues) > 0 && col.GoType == "string"
+}
+
+// enumTypeName returns the name of the generated Go type for an enum column, e.g. UserStatus.
+func (g *Generator) enumTypeName(entity EntityInfo, col ColumnInfo) string {
+	return entity.Name + g.toGoIdentifier(col.Name)
+}
+
+// enumConstName returns the constant name for an enum value, e.g. UserStatusInProgress for "in-progress".
+func (g *Generator) enumConstName(typeName, value string) string {
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		// The empty string and values without letters or digits, e.g. "-"
+		return typeName + "Empty"
+	}
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return typeName + strings.Join(words, "")
+}
+
+// generateEnumType generates a named string type for an enum column with one constant per value,
+// a Valid method and sql.Scanner / driver.Valuer implementations that reject unknown values.
+func (g *Generator) generateEnumType(entity EntityInfo, col ColumnInfo) jen.Code {
+	typeName := g.enumTypeName(entity, col)
+	group := &jen.Statement{}
+
+	group.Commentf("%s is the set of values allowed in %s.%s.", typeName, entity.Table.Name, col.Name)
+	group.Line()
+	group.Type().Id(typeName).String()
+	group.Line().Line()
+
+	var consts []jen.Code
+	var cases []jen.Code
+	values := map[string]bool{}
+	names := map[string]bool{typeName: true}
+	for _, value := range col.EnumValues {
+		if values[value] {
+			continue
+		}
+		values[value] = true
+		// Values differing only in punctuation, such as in-store and in_store, get numbered constants
+		constName := g.enumConstName(typeName, value)
+		for i := 2; names[constName]; i++ {
+			constName = fmt.Sprintf("%s%d", g.enumConstName(typeName, value), i)
+		}
+		names[constName] = true
+		consts = append(consts, jen.Id(constName).Id(typeName).Op("=").Lit(value))
+		cases = append(cases, jen.Id(constName))
+	}
+	group.Const().Defs(consts...)
+	group.Line().Line()
+
+	group.Commentf("Valid reports whether e is one of the declared %s values.", typeName)
+	group.Line()
+	group.Func().Params(jen.Id("e").Id(typeName)).Id("Valid").Params().Bool().Block(
+		jen.Switch(jen.Id("e")).Block(
+			jen.Case(cases...).Block(jen.Return(jen.True())),
+		),
+		jen.Return(jen.False()),
+	)
+	group.Line().Line()
+
+	group.Comment("Scan implements sql.Scanner.")
+	group.Line()
+	group.Func().Params(jen.Id("e").Op("*").Id(typeName)).Id("Scan").Params(jen.Id("src").Any()).Error().Block(
+		jen.Switch(jen.Id("v").Op(":=").Id("src").Assert(jen.Type())).Block(
+			jen.Case(jen.Nil()).Block(
+				jen.Op("*").Id("e").Op("=").Lit(""),
+				jen.Return(jen.Nil()),
+			),
+			jen.Case(jen.String()).Block(jen.Op("*").Id("e").Op("=").Id(typeName).Call(jen.Id("v"))),
+			jen.Case(jen.Index().Byte()).Block(jen.Op("*").Id("e").Op("=").Id(typeName).Call(jen.Id("v"))),
+			jen.Default().Block(
+				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("cannot scan %T into "+typeName), jen.Id("src"))),
+			),
+		),
+		jen.If(jen.Op("!").Id("e").Dot("Valid").Call()).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("invalid "+typeName+" value %q"), jen.String().Call(jen.Op("*").Id("e")))),
+		),
+		jen.Return(jen.Nil()),
+	)
+	group.Line().Line()
+
+	group.Comment("Value implements driver.Valuer.")
+	group.Line()
+	group.Func().Params(jen.Id("e").Id(typeName)).Id("Value").Params().Params(jen.Qual("database/sql/driver", "Value"), jen.Error()).Block(
+		jen.If(jen.Op("!").Id("e").Dot("Valid").Call()).Block(
+			jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(jen.Lit("invalid "+typeName+" value %q"), jen.String().Call(jen.Id("e")))),
+		),
+		jen.Return(jen.String().Call(jen.Id("e")), jen.Nil()),
+	)
+	group.Line()
+	return group
+}
@@ -1,15 +1,20 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/parser"
+	"go/printer"
 	"go/token"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dave/jennifer/jen"
+	"github.com/golshani-mhd/grizzle-kit/flavors"
 	"github.com/golshani-mhd/grizzle-kit/types"
 )
 
@@ -19,18 +24,65 @@ func NewGenerator(config *GeneratorConfig) *Generator { return &Generator{config
 // GenerateFromFile parses a Go file and generates entity files
 // Returns the list of generated entity names
 func (g *Generator) GenerateFromFile(filePath string) ([]string, error) {
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	entities, err := g.ParseFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse file %s: %w", filePath, err)
+		return nil, err
+	}
+	return g.GenerateEntities(entities)
+}
+
+// ParseFile parses a Go file and returns the entity definitions it contains
+func (g *Generator) ParseFile(filePath string) ([]EntityInfo, error) {
+	return g.ParseFiles(filePath)
+}
+
+// ParseFiles parses Go files together and returns the entity definitions they contain.
+// Constants, sequences and domains are collected from every file before any column is parsed,
+// so that tables can use objects declared in another file
+func (g *Generator) ParseFiles(filePaths ...string) ([]EntityInfo, error) {
+	fset := token.NewFileSet()
+	nodes := make([]*ast.File, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse file %s: %w", filePath, err)
+		}
+		nodes = append(nodes, node)
+	}
+
+	g.constants = map[string]ast.Expr{}
+	g.sequences = map[string]types.Sequence{}
+	g.domains = map[string]domainInfo{}
+	g.objects = nil
+	declared := map[string]string{}
+	for i, node := range nodes {
+		g.extractConstants(node)
+		if err := g.extractObjects(node, g.findTypesPkgAlias(node), filePaths[i], declared); err != nil {
+			return nil, err
+		}
 	}
-	entities := g.extractEntities(node)
 
+	var entities []EntityInfo
+	for i, node := range nodes {
+		fileEntities, err := g.extractEntities(node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse file %s: %w", filePaths[i], err)
+		}
+		entities = append(entities, fileEntities...)
+	}
+	return entities, nil
+}
+
+// GenerateEntities generates entity files for entities parsed together,
+// so that relations between them can be resolved
+// Returns the list of generated entity names
+func (g *Generator) GenerateEntities(entities []EntityInfo) ([]string, error) {
 	// If no entities found, return empty list
 	if len(entities) == 0 {
 		return []string{}, nil
 	}
 
+	g.resolveRelations(entities)
 	var generatedEntities []string
 	for _, entity := range entities {
 		if err := g.generateEntityFile(entity); err != nil {
@@ -38,16 +90,98 @@ func (g *Generator) GenerateFromFile(filePath string) ([]string, error) {
 		}
 		generatedEntities = append(generatedEntities, entity.Name)
 	}
+	if g.config.SQLOut != "" {
+		if err := g.writeSQL(entities); err != nil {
+			return nil, fmt.Errorf("failed to write SQL: %w", err)
+		}
+	}
 	return generatedEntities, nil
 }
 
+// resolveRelations derives the join paths of each entity from the foreign keys declared on all entities
+func (g *Generator) resolveRelations(entities []EntityInfo) {
+	// References may name the target table with or without its schema
+	byTable := make(map[string]int, len(entities))
+	for i := range entities {
+		entities[i].Relations = nil
+		byTable[entities[i].Table.QualifiedName()] = i
+	}
+	for i := range entities {
+		if _, ok := byTable[entities[i].Table.Name]; !ok {
+			byTable[entities[i].Table.Name] = i
+		}
+	}
+
+	// Count references from each table into each target, to disambiguate helpers
+	refCount := map[[2]string]int{}
+	for _, entity := range entities {
+		for _, col := range entity.Columns {
+			if col.References != nil {
+				refCount[[2]string{entity.Table.QualifiedName(), col.References.Table}]++
+			}
+		}
+	}
+
+	for _, entity := range entities {
+		for _, col := range entity.Columns {
+			fk := col.References
+			if fk == nil {
+				continue
+			}
+			// Forward relation, named after the referencing column (user_id -> User), or after the
+			// target table, disambiguated by column when several reference it (author -> UsersByAuthor)
+			forwardName := g.toGoIdentifier(strings.TrimSuffix(col.Name, "_id"))
+			if !strings.HasSuffix(col.Name, "_id") {
+				forwardName = g.toGoIdentifier(fk.Table[strings.LastIndex(fk.Table, ".")+1:])
+				if refCount[[2]string{entity.Table.QualifiedName(), fk.Table}] > 1 {
+					forwardName += "By" + g.toGoIdentifier(col.Name)
+				}
+			}
+			source := byTable[entity.Table.QualifiedName()]
+			forward := RelationInfo{
+				Name:         forwardName,
+				Column:       col.Name,
+				TargetTable:  fk.Table,
+				TargetColumn: fk.Column,
+			}
+			target, ok := byTable[fk.Table]
+			if ok {
+				forward.TargetEntity = entities[target].Name
+				forward.TargetDelete = entities[target].Table.SoftDelete
+			}
+			entities[source].Relations = append(entities[source].Relations, forward)
+
+			// Reverse relation on the referenced entity, named after the referencing table (posts -> Posts)
+			if !ok {
+				continue
+			}
+			reverseName := g.toGoIdentifier(entity.Table.Name)
+			if refCount[[2]string{entity.Table.QualifiedName(), fk.Table}] > 1 {
+				reverseName += "By" + g.toGoIdentifier(col.Name)
+			}
+			entities[target].Relations = append(entities[target].Relations, RelationInfo{
+				Name:         reverseName,
+				Column:       fk.Column,
+				TargetTable:  entity.Table.QualifiedName(),
+				TargetColumn: col.Name,
+				TargetEntity: entity.Name,
+				TargetDelete: entity.Table.SoftDelete,
+			})
+		}
+	}
+}
+
 // extractEntities extracts entity definitions from AST
-func (g *Generator) extractEntities(node *ast.File) []EntityInfo {
+func (g *Generator) extractEntities(node *ast.File) ([]EntityInfo, error) {
 	// First, find the alias for the grizzle-kit/types package
 	typesPkgAlias := g.findTypesPkgAlias(node)
 
 	var entities []EntityInfo
+	var err error
 	ast.Inspect(node, func(n ast.Node) bool {
+		if err != nil {
+			return false
+		}
 		switch x := n.(type) {
 		case *ast.GenDecl:
 			if x.Tok == token.VAR {
@@ -60,7 +194,8 @@ func (g *Generator) extractEntities(node *ast.File) []EntityInfo {
 									// Check if the type is grizzle.Table
 									if g.isTableType(compositeLit.Type, typesPkgAlias) {
 										entityName := g.deriveEntityName(name.Name)
-										if entity := g.parseTableDefinition(entityName, compositeLit); entity != nil {
+										var entity *EntityInfo
+										if entity, err = g.parseTableDefinition(entityName, compositeLit); entity != nil {
 											entities = append(entities, *entity)
 										}
 									}
@@ -73,7 +208,7 @@ func (g *Generator) extractEntities(node *ast.File) []EntityInfo {
 		}
 		return true
 	})
-	return entities
+	return entities, err
 }
 
 // findTypesPkgAlias finds the import alias for github.com/golshani-mhd/grizzle-kit/types
@@ -121,8 +256,8 @@ func (g *Generator) deriveEntityName(varName string) string {
 }
 
 // parseTableDefinition parses a Table composite literal
-func (g *Generator) parseTableDefinition(entityName string, lit *ast.CompositeLit) *EntityInfo {
-	var tableName string
+func (g *Generator) parseTableDefinition(entityName string, lit *ast.CompositeLit) (*EntityInfo, error) {
+	table := &types.Table{}
 	var columns []ColumnInfo
 
 	// Extract the package alias from the type
@@ -133,24 +268,323 @@ func (g *Generator) parseTableDefinition(entityName string, lit *ast.CompositeLi
 		}
 	}
 
+	// Plain string settings of the table
+	settings := map[string]*string{
+		"Name":        &table.Name,
+		"Schema":      &table.Schema,
+		"SoftDelete":  &table.SoftDelete,
+		"Comment":     &table.Comment,
+		"Engine":      &table.Engine,
+		"Charset":     &table.Charset,
+		"Collate":     &table.Collate,
+		"Tablespace":  &table.Tablespace,
+		"PartitionBy": &table.PartitionBy,
+	}
 	for _, elt := range lit.Elts {
 		if kv, ok := elt.(*ast.KeyValueExpr); ok {
-			switch key := kv.Key.(*ast.Ident).Name; key {
-			case "Name":
-				if str, ok := kv.Value.(*ast.BasicLit); ok {
-					tableName = strings.Trim(str.Value, "\"")
+			key := kv.Key.(*ast.Ident).Name
+			if setting, ok := settings[key]; ok {
+				value, ok := g.evalString(kv.Value, pkgAlias)
+				if !ok {
+					return nil, fmt.Errorf("%s of %s is neither a literal nor a constant of the schema files", key, entityName)
 				}
+				*setting = value
+				continue
+			}
+			switch key {
 			case "Columns":
+				columns = g.parseColumnsExpr(kv.Value, pkgAlias)
+			case "Checks":
+				if arrayLit, ok := kv.Value.(*ast.CompositeLit); ok {
+					table.Checks = g.parseChecks(arrayLit)
+				}
+			case "Indexes":
+				if arrayLit, ok := kv.Value.(*ast.CompositeLit); ok {
+					table.Indexes = g.parseIndexes(arrayLit)
+				}
+			case "OrderBy":
+				if arrayLit, ok := kv.Value.(*ast.CompositeLit); ok {
+					table.OrderBy = g.parseStrings(arrayLit)
+				}
+			case "PrimaryKey":
+				if arrayLit, ok := kv.Value.(*ast.CompositeLit); ok {
+					table.PrimaryKey = g.parseStrings(arrayLit)
+				}
+			case "PartitionKey":
 				if arrayLit, ok := kv.Value.(*ast.CompositeLit); ok {
-					columns = g.parseColumns(arrayLit, pkgAlias)
+					table.PartitionKey = g.parseStrings(arrayLit)
+				}
+			case "ClusteringKey":
+				if arrayLit, ok := kv.Value.(*ast.CompositeLit); ok {
+					table.ClusteringKey = g.parseClusteringKey(arrayLit, pkgAlias)
+				}
+			case "ClickHouse":
+				value := kv.Value
+				if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+					value = unary.X
+				}
+				if optionsLit, ok := value.(*ast.CompositeLit); ok {
+					table.ClickHouse = g.parseClickHouseOptions(optionsLit)
 				}
 			}
 		}
 	}
-	if tableName == "" {
-		return nil
+	if table.Name == "" {
+		return nil, nil
 	}
-	return &EntityInfo{Name: entityName, Table: &types.Table{Name: tableName}, Columns: columns}
+	return &EntityInfo{Name: entityName, Table: table, Columns: columns}, nil
+}
+
+// evalString evaluates a string setting of a table: a literal, a constant declared in the schema
+// files, or a ClickHouse engine such as types.ReplacingMergeTree("version")
+func (g *Generator) evalString(expr ast.Expr, pkgAlias string) (string, bool) {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", false
+		}
+		if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != pkgAlias {
+			return "", false
+		}
+		args := make([]string, len(call.Args))
+		for i, arg := range call.Args {
+			if args[i], ok = g.evalString(arg, pkgAlias); !ok {
+				return "", false
+			}
+		}
+		switch {
+		case selector.Sel.Name == "MergeTree" && len(args) == 0:
+			return types.MergeTree(), true
+		case selector.Sel.Name == "AggregatingMergeTree" && len(args) == 0:
+			return types.AggregatingMergeTree(), true
+		case selector.Sel.Name == "ReplacingMergeTree" && len(args) == 1:
+			return types.ReplacingMergeTree(args[0]), true
+		case selector.Sel.Name == "CollapsingMergeTree" && len(args) == 1:
+			return types.CollapsingMergeTree(args[0]), true
+		case selector.Sel.Name == "ReplicatedMergeTree" && len(args) == 2:
+			return types.ReplicatedMergeTree(args[0], args[1]), true
+		case selector.Sel.Name == "SummingMergeTree":
+			return types.SummingMergeTree(args...), true
+		}
+		return "", false
+	}
+	value, ok := g.evalDefault(expr, 0)
+	str, isString := value.(string)
+	return str, ok && isString
+}
+
+// parseClusteringKey parses the clustering columns of a CQL table, e.g.
+// []types.ClusteringColumn{types.Desc("created_at"), {Column: "id"}}
+func (g *Generator) parseClusteringKey(arrayLit *ast.CompositeLit, pkgAlias string) []types.ClusteringColumn {
+	var key []types.ClusteringColumn
+	for _, elt := range arrayLit.Elts {
+		switch value := elt.(type) {
+		case *ast.CallExpr:
+			selector, ok := value.Fun.(*ast.SelectorExpr)
+			if !ok || len(value.Args) != 1 {
+				continue
+			}
+			if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != pkgAlias {
+				continue
+			}
+			column, ok := g.evalString(value.Args[0], pkgAlias)
+			if !ok {
+				continue
+			}
+			switch selector.Sel.Name {
+			case "Asc":
+				key = append(key, types.Asc(column))
+			case "Desc":
+				key = append(key, types.Desc(column))
+			}
+		case *ast.CompositeLit:
+			var clustering types.ClusteringColumn
+			for _, field := range value.Elts {
+				kv, ok := field.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				switch kv.Key.(*ast.Ident).Name {
+				case "Column":
+					clustering.Column, _ = g.evalString(kv.Value, pkgAlias)
+				case "Desc":
+					if ident, ok := kv.Value.(*ast.Ident); ok {
+						clustering.Desc = ident.Name == "true"
+					}
+				}
+			}
+			key = append(key, clustering)
+		}
+	}
+	return key
+}
+
+// parseClickHouseOptions parses the ClickHouse options of a table, e.g.
+// &types.ClickHouseOptions{TTL: "ts + INTERVAL 30 DAY", Settings: map[string]string{"index_granularity": "8192"}}
+func (g *Generator) parseClickHouseOptions(lit *ast.CompositeLit) *types.ClickHouseOptions {
+	options := &types.ClickHouseOptions{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		switch kv.Key.(*ast.Ident).Name {
+		case "PrimaryKey":
+			if arrayLit, ok := kv.Value.(*ast.CompositeLit); ok {
+				options.PrimaryKey = g.parseStrings(arrayLit)
+			}
+		case "SampleBy":
+			options.SampleBy, _ = g.evalString(kv.Value, "")
+		case "TTL":
+			options.TTL, _ = g.evalString(kv.Value, "")
+		case "Settings":
+			mapLit, ok := kv.Value.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			options.Settings = map[string]string{}
+			for _, entry := range mapLit.Elts {
+				if pair, ok := entry.(*ast.KeyValueExpr); ok {
+					key, keyOk := g.evalString(pair.Key, "")
+					value, valueOk := g.evalString(pair.Value, "")
+					if keyOk && valueOk {
+						options.Settings[key] = value
+					}
+				}
+			}
+		}
+	}
+	return options
+}
+
+// parseChecks parses table-level CHECK constraints, e.g. []types.Check{{Name: "...", Expr: "..."}}
+func (g *Generator) parseChecks(arrayLit *ast.CompositeLit) []types.Check {
+	var checks []types.Check
+	for _, elt := range arrayLit.Elts {
+		lit, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		var check types.Check
+		for _, field := range lit.Elts {
+			kv, ok := field.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, keyOk := kv.Key.(*ast.Ident)
+			str, strOk := kv.Value.(*ast.BasicLit)
+			if !keyOk || !strOk {
+				continue
+			}
+			switch key.Name {
+			case "Name":
+				check.Name = strings.Trim(str.Value, "\"")
+			case "Expr":
+				check.Expr = strings.Trim(str.Value, "\"")
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// parseIndexes parses the indexes of a table, e.g. []types.Index{{Columns: []string{"email"}, Unique: true}}
+func (g *Generator) parseIndexes(arrayLit *ast.CompositeLit) []types.Index {
+	var indexes []types.Index
+	for _, elt := range arrayLit.Elts {
+		lit, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		var index types.Index
+		for _, field := range lit.Elts {
+			kv, ok := field.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			switch value := kv.Value.(type) {
+			case *ast.BasicLit:
+				if key.Name == "Name" {
+					index.Name = strings.Trim(value.Value, "\"")
+				}
+			case *ast.CompositeLit:
+				if key.Name == "Columns" {
+					index.Columns = g.parseStrings(value)
+				}
+			case *ast.Ident:
+				if key.Name == "Unique" {
+					index.Unique = value.Name == "true"
+				}
+			}
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// parseStrings parses a []string literal
+func (g *Generator) parseStrings(arrayLit *ast.CompositeLit) []string {
+	var values []string
+	for _, elt := range arrayLit.Elts {
+		if str, ok := elt.(*ast.BasicLit); ok && str.Kind == token.STRING {
+			values = append(values, strings.Trim(str.Value, "\""))
+		}
+	}
+	return values
+}
+
+// parseColumnsExpr parses the Columns of a table: an array literal, types.Timestamps(), or
+// append(...) of those, e.g. append([]*types.Column[any]{...}, types.Timestamps()...)
+func (g *Generator) parseColumnsExpr(expr ast.Expr, pkgAlias string) []ColumnInfo {
+	switch v := expr.(type) {
+	case *ast.CompositeLit:
+		return g.parseColumns(v, pkgAlias)
+	case *ast.CallExpr:
+		if ident, ok := v.Fun.(*ast.Ident); ok && ident.Name == "append" && len(v.Args) > 0 {
+			columns := g.parseColumnsExpr(v.Args[0], pkgAlias)
+			for i, arg := range v.Args[1:] {
+				if v.Ellipsis.IsValid() && i == len(v.Args)-2 {
+					columns = append(columns, g.parseColumnsExpr(arg, pkgAlias)...)
+				} else if call, ok := arg.(*ast.CallExpr); ok {
+					if column := g.parseColumnCall(call, pkgAlias); column != nil {
+						columns = append(columns, *column)
+					}
+				}
+			}
+			return columns
+		}
+		if g.isTimestampsCall(v, pkgAlias) {
+			return g.timestampColumns()
+		}
+	}
+	return nil
+}
+
+// isTimestampsCall reports whether call is types.Timestamps()
+func (g *Generator) isTimestampsCall(call *ast.CallExpr, pkgAlias string) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Timestamps" {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	return ok && ident.Name == pkgAlias
+}
+
+// timestampColumns returns the columns added by types.Timestamps()
+func (g *Generator) timestampColumns() []ColumnInfo {
+	goType, abstractType := g.getTypeInfo("Timestamp")
+	if override, ok := g.typeOverride(abstractType); ok {
+		goType = override
+	}
+	var columns []ColumnInfo
+	for _, name := range []string{"created_at", "updated_at"} {
+		columns = append(columns, ColumnInfo{Name: name, GoType: goType, AbstractType: abstractType, HasDefault: true, DefaultExpr: &types.Expr{Func: "now"}, OnUpdateNow: name == "updated_at"})
+	}
+	return columns
 }
 
 // parseColumns parses column definitions from array literal
@@ -168,20 +602,78 @@ func (g *Generator) parseColumns(arrayLit *ast.CompositeLit, pkgAlias string) []
 
 // parseColumnCall parses a column function call (e.g., Int, Varchar, etc.)
 func (g *Generator) parseColumnCall(call *ast.CallExpr, pkgAlias string) *ColumnInfo {
-	var columnName, goType, sqlType, abstractType string
+	var columnName, goType, sqlType, unresolvedType, abstractType string
 	var autoIncrement, hasDefault bool
 	var defaultValue interface{}
 	var length, precision, scale *int
+	var references *types.ForeignKey
+	var enumValues []string
+	var check string
+	var generated *types.Generated
+	var defaultExpr *types.Expr
+	var onUpdateNow bool
+	var comment string
+	var lowCardinality, nullable bool
+	var domain string
 
-	if ident, ok := call.Fun.(*ast.Ident); ok {
+	// Generic factories such as List[string] carry their Go element types as type arguments
+	fun := call.Fun
+	var typeArgs []ast.Expr
+	switch index := fun.(type) {
+	case *ast.IndexExpr:
+		fun, typeArgs = index.X, []ast.Expr{index.Index}
+	case *ast.IndexListExpr:
+		fun, typeArgs = index.X, index.Indices
+	}
+	// Domain columns are written as Email.Column(name, ...) or types.DomainColumn(Email, name, ...)
+	var domainRef ast.Expr
+	if ident, ok := fun.(*ast.Ident); ok {
 		funcName := ident.Name
-		goType, sqlType, abstractType = g.getTypeInfo(funcName)
-	} else if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+		goType, abstractType = g.getTypeInfo(funcName)
+	} else if selector, ok := fun.(*ast.SelectorExpr); ok {
 		if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == pkgAlias {
 			funcName := selector.Sel.Name
-			goType, sqlType, abstractType = g.getTypeInfo(funcName)
+			if funcName == "DomainColumn" && len(call.Args) > 0 {
+				domainRef, call = call.Args[0], &ast.CallExpr{Fun: call.Fun, Args: call.Args[1:]}
+			} else {
+				goType, abstractType = g.getTypeInfo(funcName)
+			}
+		} else if selector.Sel.Name == "Column" {
+			domainRef = selector.X
 		}
 	}
+	if name, ok := objectRef(domainRef); ok {
+		if info, ok := g.domains[name]; ok {
+			// Domain columns take their Go type and size from the domain's base type
+			goType, _ = g.getTypeInfo(strings.TrimPrefix(info.AbstractType, "ColumnType"))
+			abstractType, domain = info.AbstractType, info.QualifiedName()
+			length, precision, scale = info.Length, info.Precision, info.Scale
+		}
+	}
+
+	// Collection element types are the ColumnType arguments after the name
+	var elementType, keyType string
+	if collectionGoType, ok := g.collectionGoType(abstractType, typeArgs); ok {
+		goType = collectionGoType
+		var columnTypes []string
+		for i := 1; i < len(call.Args); i++ {
+			if selector, ok := call.Args[i].(*ast.SelectorExpr); ok && strings.HasPrefix(selector.Sel.Name, "ColumnType") {
+				columnTypes = append(columnTypes, selector.Sel.Name)
+			}
+		}
+		if abstractType == "ColumnTypeCQLMap" && len(columnTypes) > 1 {
+			keyType, elementType = columnTypes[0], columnTypes[1]
+		} else if len(columnTypes) > 0 {
+			elementType = columnTypes[0]
+		}
+	}
+	if abstractType == "ColumnTypeMySQLSet" {
+		elementType = "ColumnTypeText"
+	}
+
+	if override, ok := g.typeOverride(abstractType); ok {
+		goType = override
+	}
 
 	if len(call.Args) > 0 {
 		if str, ok := call.Args[0].(*ast.BasicLit); ok {
@@ -189,6 +681,20 @@ func (g *Generator) parseColumnCall(call *ast.CallExpr, pkgAlias string) *Column
 		}
 	}
 
+	// EnumWith and SetOf values are passed as a []string literal after the name, Enum values as
+	// the remaining arguments
+	if (abstractType == "ColumnTypeMySQLEnum" || abstractType == "ColumnTypeMySQLSet") && len(call.Args) > 1 {
+		values := call.Args[1:]
+		if lit, ok := call.Args[1].(*ast.CompositeLit); ok {
+			values = lit.Elts
+		}
+		for _, value := range values {
+			if str, ok := value.(*ast.BasicLit); ok && str.Kind == token.STRING {
+				enumValues = append(enumValues, strings.Trim(str.Value, "\""))
+			}
+		}
+	}
+
 	for i := 1; i < len(call.Args); i++ {
 		if callExpr, ok := call.Args[i].(*ast.CallExpr); ok {
 			var funcName string
@@ -215,18 +721,49 @@ func (g *Generator) parseColumnCall(call *ast.CallExpr, pkgAlias string) *Column
 					}
 				}
 			case "WithType":
+				// A types constant also sets the abstract type, as WithType does
 				if len(callExpr.Args) > 0 {
+					var ct types.ColumnType
+					var isColumnType bool
 					if selector, ok := callExpr.Args[0].(*ast.SelectorExpr); ok {
 						if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == pkgAlias {
-							sqlType = selector.Sel.Name
+							ct, isColumnType = columnTypes[selector.Sel.Name]
 						}
 					}
+					if isColumnType {
+						sqlType, abstractType = ct.String(), columnTypeName(ct)
+					} else if str, ok := g.evalString(callExpr.Args[0], pkgAlias); ok {
+						sqlType = str
+					} else {
+						var buf bytes.Buffer
+						printer.Fprint(&buf, token.NewFileSet(), callExpr.Args[0])
+						unresolvedType = buf.String()
+					}
 				}
 			case "WithDefault":
 				hasDefault = true
 				if len(callExpr.Args) > 0 {
 					defaultValue = g.parseDefaultValue(callExpr.Args[0], goType)
 				}
+			case "WithDefaultExpr":
+				if len(callExpr.Args) > 0 {
+					if expr := g.parseDefaultExpr(callExpr.Args[0], pkgAlias); expr != nil {
+						hasDefault = true
+						defaultExpr = expr
+					}
+				}
+			case "WithOnUpdateNow":
+				onUpdateNow = true
+			case "WithLowCardinality":
+				lowCardinality = true
+			case "WithNullable":
+				nullable = true
+			case "WithComment":
+				if len(callExpr.Args) > 0 {
+					if str, ok := callExpr.Args[0].(*ast.BasicLit); ok {
+						comment = strings.Trim(str.Value, "\"")
+					}
+				}
 			case "WithLength":
 				if len(callExpr.Args) > 0 {
 					if intLit, ok := callExpr.Args[0].(*ast.BasicLit); ok {
@@ -235,6 +772,35 @@ func (g *Generator) parseColumnCall(call *ast.CallExpr, pkgAlias string) *Column
 						}
 					}
 				}
+			case "WithGoType":
+				if len(callExpr.Args) > 0 {
+					if str, ok := callExpr.Args[0].(*ast.BasicLit); ok {
+						goType = strings.Trim(str.Value, "\"")
+					}
+				}
+			case "WithCheck":
+				if len(callExpr.Args) > 0 {
+					if str, ok := callExpr.Args[0].(*ast.BasicLit); ok {
+						check = strings.Trim(str.Value, "\"")
+					}
+				}
+			case "WithGenerated":
+				if len(callExpr.Args) > 1 {
+					if str, ok := callExpr.Args[0].(*ast.BasicLit); ok {
+						generated = &types.Generated{Expr: strings.Trim(str.Value, "\""), Kind: types.Virtual}
+						if selector, ok := callExpr.Args[1].(*ast.SelectorExpr); ok && selector.Sel.Name == "Stored" {
+							generated.Kind = types.Stored
+						}
+					}
+				}
+			case "WithReferences":
+				if len(callExpr.Args) > 1 {
+					table, tableOk := callExpr.Args[0].(*ast.BasicLit)
+					column, columnOk := callExpr.Args[1].(*ast.BasicLit)
+					if tableOk && columnOk {
+						references = &types.ForeignKey{Table: strings.Trim(table.Value, "\""), Column: strings.Trim(column.Value, "\"")}
+					}
+				}
 			case "WithPrecision":
 				if len(callExpr.Args) > 1 {
 					if intLit, ok := callExpr.Args[0].(*ast.BasicLit); ok {
@@ -251,80 +817,274 @@ func (g *Generator) parseColumnCall(call *ast.CallExpr, pkgAlias string) *Column
 			}
 		}
 	}
-	return &ColumnInfo{Name: columnName, GoType: goType, SQLType: sqlType, AbstractType: abstractType, AutoIncrement: autoIncrement, HasDefault: hasDefault, DefaultValue: defaultValue, Length: length, Precision: precision, Scale: scale}
-}
-
-func (g *Generator) getTypeInfo(funcName string) (goType, sqlType, abstractType string) {
-	typeMap := map[string]struct{ goType, sqlType, abstractType string }{
-		"Varchar":   {"string", "Varchar", "ColumnTypeVarchar"},
-		"Char":      {"string", "Char", "ColumnTypeChar"},
-		"Text":      {"string", "Text", "ColumnTypeText"},
-		"TinyInt":   {"int8", "TinyInt", "ColumnTypeTinyInt"},
-		"SmallInt":  {"int16", "SmallInt", "ColumnTypeSmallInt"},
-		"Int":       {"int32", "Int", "ColumnTypeInt"},
-		"BigInt":    {"int64", "BigInt", "ColumnTypeBigInt"},
-		"Boolean":   {"bool", "Boolean", "ColumnTypeBoolean"},
-		"Real":      {"float32", "Real", "ColumnTypeReal"},
-		"Double":    {"float64", "Double", "ColumnTypeDouble"},
-		"Decimal":   {"string", "Decimal", "ColumnTypeDecimal"},
-		"Date":      {"time.Time", "Date", "ColumnTypeDate"},
-		"Time":      {"time.Time", "Time", "ColumnTypeTime"},
-		"DateTime":  {"time.Time", "DateTime", "ColumnTypeDateTime"},
-		"Timestamp": {"time.Time", "Timestamp", "ColumnTypeTimestamp"},
-		"Blob":      {"[]byte", "Blob", "ColumnTypeBlob"},
-		"Json":      {"string", "Json", "ColumnTypeJson"},
-		"Uuid":      {"string", "Uuid", "ColumnTypeUuid"},
-		"Bit":       {"int64", "Bit", "ColumnTypeBit"},
-		"Binary":    {"[]byte", "Binary", "ColumnTypeBinary"},
-		"Varbinary": {"[]byte", "Varbinary", "ColumnTypeVarbinary"},
-		"Money":     {"string", "Money", "ColumnTypeMoney"},
-		"Xml":       {"string", "Xml", "ColumnTypeXml"},
+	return &ColumnInfo{Name: columnName, GoType: goType, SQLType: sqlType, UnresolvedType: unresolvedType, AbstractType: abstractType, AutoIncrement: autoIncrement, HasDefault: hasDefault, DefaultValue: defaultValue, Length: length, Precision: precision, Scale: scale, References: references, EnumValues: enumValues, Check: check, Generated: generated, DefaultExpr: defaultExpr, OnUpdateNow: onUpdateNow, Comment: comment, LowCardinality: lowCardinality, Nullable: nullable, ElementType: elementType, KeyType: keyType, Domain: domain}
+}
+
+// collectionGoType returns the Go type of a collection column from the type arguments of its factory,
+// e.g. []string for List[string] and map[string]int64 for Map[string, int64].
+func (g *Generator) collectionGoType(abstractType string, typeArgs []ast.Expr) (string, bool) {
+	args := make([]string, len(typeArgs))
+	for i, arg := range typeArgs {
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, token.NewFileSet(), arg); err != nil {
+			return "", false
+		}
+		args[i] = buf.String()
+	}
+	switch abstractType {
+	case "ColumnTypePostgresArray", "ColumnTypeCQLList", "ColumnTypeCQLSet":
+		if len(args) == 1 {
+			return "[]" + args[0], true
+		}
+	case "ColumnTypeCQLMap":
+		if len(args) == 2 {
+			return "map[" + args[0] + "]" + args[1], true
+		}
+	}
+	return "", false
+}
+
+func (g *Generator) getTypeInfo(funcName string) (goType, abstractType string) {
+	typeMap := map[string]struct{ goType, abstractType string }{
+		"Varchar":   {"string", "ColumnTypeVarchar"},
+		"Char":      {"string", "ColumnTypeChar"},
+		"Text":      {"string", "ColumnTypeText"},
+		"TinyInt":   {"int8", "ColumnTypeTinyInt"},
+		"SmallInt":  {"int16", "ColumnTypeSmallInt"},
+		"Int":       {"int32", "ColumnTypeInt"},
+		"BigInt":    {"int64", "ColumnTypeBigInt"},
+		"Boolean":   {"bool", "ColumnTypeBoolean"},
+		"Real":      {"float32", "ColumnTypeReal"},
+		"Double":    {"float64", "ColumnTypeDouble"},
+		"Decimal":   {"string", "ColumnTypeDecimal"},
+		"Date":      {"time.Time", "ColumnTypeDate"},
+		"Time":      {"time.Time", "ColumnTypeTime"},
+		"DateTime":  {"time.Time", "ColumnTypeDateTime"},
+		"Timestamp": {"time.Time", "ColumnTypeTimestamp"},
+		"Blob":      {"[]byte", "ColumnTypeBlob"},
+		"Json":      {"string", "ColumnTypeJson"},
+		"Uuid":      {"string", "ColumnTypeUuid"},
+		"Bit":       {"int64", "ColumnTypeBit"},
+		"Binary":    {"[]byte", "ColumnTypeBinary"},
+		"Varbinary": {"[]byte", "ColumnTypeVarbinary"},
+		"Money":     {"string", "ColumnTypeMoney"},
+		"Xml":       {"string", "ColumnTypeXml"},
+		"Enum":      {"string", "ColumnTypeMySQLEnum"},
+		"EnumWith":  {"string", "ColumnTypeMySQLEnum"},
+		"Counter":   {"int64", "ColumnTypeCQLCounter"},
+		"Array":     {"[]interface{}", "ColumnTypePostgresArray"},
+		"List":      {"[]interface{}", "ColumnTypeCQLList"},
+		"Set":       {"[]interface{}", "ColumnTypeCQLSet"},
+		"SetOf":     {"[]string", "ColumnTypeMySQLSet"},
+		"Map":       {"map[interface{}]interface{}", "ColumnTypeCQLMap"},
 	}
 	if info, exists := typeMap[funcName]; exists {
-		return info.goType, info.sqlType, info.abstractType
+		return info.goType, info.abstractType
 	}
-	return "interface{}", "Unknown", "ColumnTypeUnknown"
+	return "interface{}", "ColumnTypeUnknown"
 }
 
+// parseDefaultValue evaluates the argument of WithDefault: a literal, a constant declared in the
+// schema files or time.Date(...) with literal arguments. Anything else cannot be written into the
+// generated packages, which do not see the schema package, and is kept as an unresolvedDefault.
 func (g *Generator) parseDefaultValue(expr ast.Expr, goType string) interface{} {
+	if value, ok := g.evalDefault(expr, 0); ok {
+		return value
+	}
+	var buf bytes.Buffer
+	printer.Fprint(&buf, token.NewFileSet(), expr)
+	return unresolvedDefault(buf.String())
+}
+
+// evalDefault evaluates a constant default value expression, following constants up to a fixed depth.
+func (g *Generator) evalDefault(expr ast.Expr, depth int) (interface{}, bool) {
 	switch lit := expr.(type) {
 	case *ast.BasicLit:
 		switch lit.Kind {
 		case token.INT:
 			if val, err := parseInt(lit.Value); err == nil {
-				return val
+				return val, true
 			}
 		case token.FLOAT:
 			if val, err := parseFloat(lit.Value); err == nil {
-				return val
+				return val, true
 			}
 		case token.STRING:
-			return strings.Trim(lit.Value, "\"")
+			if val, err := strconv.Unquote(lit.Value); err == nil {
+				return val, true
+			}
 		case token.CHAR:
-			return strings.Trim(lit.Value, "'")
+			return strings.Trim(lit.Value, "'"), true
+		}
+	case *ast.ParenExpr:
+		return g.evalDefault(lit.X, depth)
+	case *ast.UnaryExpr:
+		if value, ok := g.evalDefault(lit.X, depth); ok && lit.Op == token.SUB {
+			switch v := value.(type) {
+			case int:
+				return -v, true
+			case float64:
+				return -v, true
+			}
 		}
 	case *ast.Ident:
-		if lit.Name == "true" {
-			return true
+		switch lit.Name {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
 		}
-		if lit.Name == "false" {
-			return false
+		if value, ok := g.constants[lit.Name]; ok && value != nil && depth < 10 {
+			return g.evalDefault(value, depth+1)
+		}
+	case *ast.CallExpr:
+		return g.evalTimeDate(lit)
+	}
+	return nil, false
+}
+
+// evalTimeDate evaluates time.Date(year, month, day, hour, min, sec, nsec, time.UTC) with literal arguments.
+func (g *Generator) evalTimeDate(call *ast.CallExpr) (interface{}, bool) {
+	if !isSelector(call.Fun, "time", "Date") || len(call.Args) != 8 || !isSelector(call.Args[7], "time", "UTC") {
+		return nil, false
+	}
+	var args [7]int
+	for i, arg := range call.Args[:7] {
+		if selector, ok := arg.(*ast.SelectorExpr); ok && i == 1 && isSelector(selector, "time", selector.Sel.Name) {
+			month, ok := months[selector.Sel.Name]
+			if !ok {
+				return nil, false
+			}
+			args[i] = int(month)
+			continue
+		}
+		value, ok := g.evalDefault(arg, 0)
+		if n, isInt := value.(int); ok && isInt {
+			args[i] = n
+			continue
+		}
+		return nil, false
+	}
+	return time.Date(args[0], time.Month(args[1]), args[2], args[3], args[4], args[5], args[6], time.UTC), true
+}
+
+// months maps the time.Month constants to their values
+var months = func() map[string]time.Month {
+	m := map[string]time.Month{}
+	for month := time.January; month <= time.December; month++ {
+		m[month.String()] = month
+	}
+	return m
+}()
+
+// isSelector reports whether expr is pkg.name
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != name {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+// unresolvedDefault is the Go source of a default value the generator could not evaluate
+type unresolvedDefault string
+
+// defaultExprConstructors maps portable expression functions to their types constructors
+var defaultExprConstructors = map[string]string{
+	"now":          "Now",
+	"current_date": "CurrentDate",
+	"random_uuid":  "RandomUUID",
+}
+
+// parseDefaultExpr parses the argument of WithDefaultExpr, e.g. types.Now() or types.SQL("...")
+func (g *Generator) parseDefaultExpr(expr ast.Expr, pkgAlias string) *types.Expr {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	// Sequences declared next to the tables, e.g. OrderNumbers.NextVal()
+	if name, ok := objectRef(selector.X); ok && selector.Sel.Name == "NextVal" {
+		if sequence, ok := g.sequences[name]; ok {
+			return &types.Expr{Func: "nextval", Raw: sequence.QualifiedName()}
+		}
+	}
+	if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != pkgAlias {
+		return nil
+	}
+	// SQL and NextVal carry their SQL text or sequence name as a string argument
+	if fn, ok := map[string]string{"SQL": "", "NextVal": "nextval"}[selector.Sel.Name]; ok {
+		if len(call.Args) > 0 {
+			if str, ok := call.Args[0].(*ast.BasicLit); ok {
+				return &types.Expr{Func: fn, Raw: strings.Trim(str.Value, "\"")}
+			}
+		}
+		return nil
+	}
+	for fn, constructor := range defaultExprConstructors {
+		if constructor == selector.Sel.Name {
+			return &types.Expr{Func: fn}
 		}
 	}
 	return nil
 }
 
+// generateDefaultExpr renders a default expression as a call to its types constructor
+func (g *Generator) generateDefaultExpr(expr *types.Expr) jen.Code {
+	var call jen.Code
+	if constructor, ok := defaultExprConstructors[expr.Func]; ok {
+		call = jen.Qual("github.com/golshani-mhd/grizzle-kit/types", constructor).Call()
+	} else if expr.Func == "nextval" {
+		call = jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "NextVal").Call(jen.Lit(expr.Raw))
+	} else {
+		call = jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "SQL").Call(jen.Lit(expr.Raw))
+	}
+	return jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "Ptr").Call(call)
+}
+
 func (g *Generator) generateEntityFile(entity EntityInfo) error {
+	if g.config.Flavor != "" {
+		if _, err := flavors.ParseFlavor(g.config.Flavor); err != nil {
+			return err
+		}
+	}
+	for _, col := range entity.Columns {
+		if src, ok := col.DefaultValue.(unresolvedDefault); ok {
+			return fmt.Errorf("default value %s of column %s is neither a literal nor a constant of the schema files", src, col.Name)
+		}
+	}
+	if softDelete := entity.Table.SoftDelete; softDelete != "" && g.softDeleteColumn(entity) == nil {
+		return fmt.Errorf("soft-delete column %s not found in table %s", softDelete, entity.Table.Name)
+	}
 	entityDir := filepath.Join(g.config.OutputDir, strings.ToLower(entity.Name))
 	file := jen.NewFile(strings.ToLower(entity.Name))
 	file.HeaderComment("Code generated by grizzle-kit. DO NOT EDIT.")
-	file.Const().Id("TABLE_NAME").Op("=").Lit(entity.Table.Name)
+	file.ImportName("github.com/huandu/go-sqlbuilder", "sqlbuilder")
+	file.Const().Id("TABLE_NAME").Op("=").Lit(entity.Table.QualifiedName())
 	file.Line()
+	createSQL, err := g.generateCreateSQL(entity)
+	if err != nil {
+		return err
+	}
+	if createSQL != nil {
+		file.Add(createSQL)
+		file.Line()
+	}
 	file.Add(g.generateSchema(entity))
 	file.Line()
 	file.Add(g.generateColumnStringVars(entity))
 	file.Line()
 	file.Add(g.generateAsMethod(entity))
+	file.Line()
+	file.Add(g.generateQueryHelpers(entity))
+	if len(entity.Relations) > 0 {
+		file.Line()
+		file.Add(g.generateJoinHelpers(entity))
+	}
 	filePath := filepath.Join(entityDir, strings.ToLower(entity.Name)+".go")
 	if err := os.MkdirAll(entityDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory %s: %w", entityDir, err)
@@ -339,79 +1099,330 @@ func (g *Generator) generateEntityFile(entity EntityInfo) error {
 }
 
 func (g *Generator) generateSchema(entity EntityInfo) jen.Code {
-	// Build struct type: var Schema = struct { FieldName *types.Column[T] ... }{ ... }
+	// Build struct type: type EntityTable struct { FieldName *types.Column[T] ... }; var Schema = EntityTable{ ... }
 	var fields []jen.Code
 	dict := jen.Dict{}
 	for _, col := range entity.Columns {
 		goName := g.toGoIdentifier(col.Name)
-		field := jen.Id(goName).Op("*").Qual("github.com/golshani-mhd/grizzle-kit/types", "Column").Index(jen.Id(col.GoType))
+		field := jen.Id(goName).Op("*").Qual("github.com/golshani-mhd/grizzle-kit/types", "Column").Index(g.goTypeCode(col.GoType))
+		if col.Comment != "" {
+			fields = append(fields, jen.Comment(col.Comment))
+		}
 		fields = append(fields, field)
 
+		// SQL types set with WithType as a string are kept as written
+		sqlType := jen.Qual("github.com/golshani-mhd/grizzle-kit/types", col.AbstractType).Dot("String").Call()
+		if ct, ok := columnTypes[col.AbstractType]; col.SQLType != "" && (!ok || ct.String() != col.SQLType) {
+			sqlType = jen.Lit(col.SQLType)
+		}
 		initDict := jen.Dict{
 			jen.Id("AbstractType"): jen.Qual("github.com/golshani-mhd/grizzle-kit/types", col.AbstractType),
 			jen.Id("Name"):         jen.Lit(col.Name),
 			jen.Id("ParentAlias"):  jen.Lit(entity.Table.Name),
-			jen.Id("Type"):         jen.Qual("github.com/golshani-mhd/grizzle-kit/types", col.AbstractType).Dot("String").Call(),
+			jen.Id("Type"):         sqlType,
 		}
 		if col.AutoIncrement {
 			initDict[jen.Id("AutoIncrement")] = jen.Lit(true)
 		}
 		if col.HasDefault {
 			initDict[jen.Id("HasDefault")] = jen.Lit(true)
-			initDict[jen.Id("Default")] = g.generateDefaultValue(col.DefaultValue, col.GoType)
+			// Literals can only be written for known types; custom Go types keep their zero value
+			if col.DefaultExpr != nil {
+				initDict[jen.Id("DefaultExpr")] = g.generateDefaultExpr(col.DefaultExpr)
+			} else if isBuiltinGoType(col.GoType) {
+				initDict[jen.Id("Default")] = g.generateDefaultValue(col.DefaultValue, col.GoType)
+			}
+		}
+		if col.Domain != "" {
+			initDict[jen.Id("Domain")] = jen.Lit(col.Domain)
+		}
+		if !isBuiltinGoType(col.GoType) && col.GoType != "interface{}" && col.ElementType == "" {
+			initDict[jen.Id("GoType")] = jen.Lit(col.GoType)
 		}
 		if col.Length != nil {
-			initDict[jen.Id("Length")] = jen.Op("&").Lit(*col.Length)
+			initDict[jen.Id("Length")] = g.intPtr(*col.Length)
 		}
 		if col.Precision != nil {
-			initDict[jen.Id("Precision")] = jen.Op("&").Lit(*col.Precision)
+			initDict[jen.Id("Precision")] = g.intPtr(*col.Precision)
 		}
 		if col.Scale != nil {
-			initDict[jen.Id("Scale")] = jen.Op("&").Lit(*col.Scale)
+			initDict[jen.Id("Scale")] = g.intPtr(*col.Scale)
+		}
+		if len(col.EnumValues) > 0 {
+			var values []jen.Code
+			for _, v := range col.EnumValues {
+				values = append(values, jen.Lit(v))
+			}
+			initDict[jen.Id("EnumValues")] = jen.Index().String().Values(values...)
+		}
+		if col.Check != "" {
+			initDict[jen.Id("Check")] = jen.Lit(col.Check)
+		}
+		if col.Generated != nil {
+			initDict[jen.Id("Generated")] = jen.Op("&").Qual("github.com/golshani-mhd/grizzle-kit/types", "Generated").Values(jen.Dict{
+				jen.Id("Expr"): jen.Lit(col.Generated.Expr),
+				jen.Id("Kind"): jen.Qual("github.com/golshani-mhd/grizzle-kit/types", g.generatedKindName(col.Generated.Kind)),
+			})
+		}
+		if col.OnUpdateNow {
+			initDict[jen.Id("OnUpdateNow")] = jen.Lit(true)
 		}
-		dict[jen.Id(goName)] = jen.Op("&").Qual("github.com/golshani-mhd/grizzle-kit/types", "Column").Index(jen.Id(col.GoType)).Values(initDict)
+		if col.Comment != "" {
+			initDict[jen.Id("Comment")] = jen.Lit(col.Comment)
+		}
+		if col.LowCardinality {
+			initDict[jen.Id("LowCardinality")] = jen.Lit(true)
+		}
+		if col.Nullable {
+			initDict[jen.Id("Nullable")] = jen.Lit(true)
+		}
+		if col.ElementType != "" {
+			initDict[jen.Id("ElementType")] = jen.Qual("github.com/golshani-mhd/grizzle-kit/types", col.ElementType)
+		}
+		if col.KeyType != "" {
+			initDict[jen.Id("KeyType")] = jen.Qual("github.com/golshani-mhd/grizzle-kit/types", col.KeyType)
+		}
+		if col.References != nil {
+			initDict[jen.Id("References")] = jen.Op("&").Qual("github.com/golshani-mhd/grizzle-kit/types", "ForeignKey").Values(jen.Dict{
+				jen.Id("Table"):  jen.Lit(col.References.Table),
+				jen.Id("Column"): jen.Lit(col.References.Column),
+			})
+		}
+		dict[jen.Id(goName)] = jen.Op("&").Qual("github.com/golshani-mhd/grizzle-kit/types", "Column").Index(g.goTypeCode(col.GoType)).Values(initDict)
 	}
-	anonStruct := jen.Struct(fields...)
-	return jen.Var().Id("Schema").Op("=").Add(anonStruct).Values(dict)
+	fields = append(fields, jen.Id("alias").String())
+	tableTypeName := g.tableTypeName(entity)
+	structType := g.tableComment(entity).Type().Id(tableTypeName).Struct(fields...)
+	return jen.Add(structType).Line().Line().Var().Id("Schema").Op("=").Id(tableTypeName).Values(dict)
 }
 
 func (g *Generator) generateColumnStringVars(entity EntityInfo) jen.Code {
 	// Generate: var Id = Schema.Id.String() ...
+	// or, when a flavor is configured: var Id = Schema.Id.Quoted(flavors.PostgreSQL) ...
 	group := &jen.Statement{}
 	for _, col := range entity.Columns {
 		goName := g.toGoIdentifier(col.Name)
-		group.Add(jen.Var().Id(goName).Op("=").Id("Schema").Dot(goName).Dot("String").Call())
+		group.Add(jen.Var().Id(goName).Op("=").Add(g.columnRef(jen.Id("Schema").Dot(goName))))
 		group.Line()
 	}
 	return group
 }
 
-func (g *Generator) generateAsMethod(entity EntityInfo) jen.Code {
-	entityName := entity.Name
-	aliasedEntityName := entityName + "Aliased"
-	var fields []jen.Code
-	for _, col := range entity.Columns {
-		goName := g.toGoIdentifier(col.Name)
-		field := jen.Id(goName).String()
-		fields = append(fields, field)
+// generatedKindName returns the types constant name of a generated column kind.
+func (g *Generator) generatedKindName(kind types.GeneratedKind) string {
+	if kind == types.Stored {
+		return "Stored"
+	}
+	return "Virtual"
+}
+
+// intPtr renders a *int literal, since &<constant> is not valid Go.
+func (g *Generator) intPtr(v int) jen.Code {
+	return jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "Ptr").Call(jen.Lit(v))
+}
+
+// flavor returns the configured flavor, if any.
+func (g *Generator) flavor() (flavors.Flavor, bool) {
+	if g.config.Flavor == "" {
+		return 0, false
+	}
+	flavor, err := flavors.ParseFlavor(g.config.Flavor)
+	if err != nil {
+		return 0, false
+	}
+	return flavor, true
+}
+
+// flavorQual returns the flavors.<Flavor> expression for the configured flavor, or nil when none is set.
+// Registered flavors, which generated code cannot name, are replaced by the built-in flavor they follow.
+func (g *Generator) flavorQual() *jen.Statement {
+	flavor, ok := g.flavor()
+	if !ok {
+		return nil
+	}
+	return jen.Qual("github.com/golshani-mhd/grizzle-kit/flavors", flavor.Base().String())
+}
+
+// columnRef renders a column reference, quoted for the configured flavor if any.
+func (g *Generator) columnRef(column *jen.Statement) *jen.Statement {
+	if flavor := g.flavorQual(); flavor != nil {
+		return column.Dot("Quoted").Call(flavor)
 	}
-	structType := jen.Type().Id(aliasedEntityName).Struct(fields...)
+	return column.Dot("String").Call()
+}
+
+// identifierRef renders an identifier expression, quoted for the configured flavor if any.
+func (g *Generator) identifierRef(identifier jen.Code) *jen.Statement {
+	if flavor := g.flavorQual(); flavor != nil {
+		return flavor.Dot("Quote").Call(identifier)
+	}
+	return jen.Add(identifier)
+}
+
+// tableRef renders TABLE_NAME, quoted for the configured flavor if any.
+func (g *Generator) tableRef(entity EntityInfo) *jen.Statement {
+	if flavor := g.flavorQual(); flavor != nil && entity.Table.Schema != "" {
+		return flavor.Dot("QuoteQualified").Call(jen.Id("TABLE_NAME"))
+	}
+	return g.identifierRef(jen.Id("TABLE_NAME"))
+}
+
+// tableComment renders the table comment, if any, as the doc comment of a generated type.
+func (g *Generator) tableComment(entity EntityInfo) *jen.Statement {
+	if entity.Table.Comment == "" {
+		return &jen.Statement{}
+	}
+	return jen.Comment(entity.Table.Comment).Line()
+}
+
+func (g *Generator) generateAsMethod(entity EntityInfo) jen.Code {
+	// As returns a copy of Schema whose columns keep their types but are qualified by the alias
+	tableTypeName := g.tableTypeName(entity)
 	dict := jen.Dict{}
 	for _, col := range entity.Columns {
 		goName := g.toGoIdentifier(col.Name)
-		dict[jen.Id(goName)] = jen.Id("Schema").Dot(goName).Dot("WithAlias").Call(jen.Id("alias")).Dot("String").Call()
+		dict[jen.Id(goName)] = jen.Id("Schema").Dot(goName).Dot("WithAlias").Call(jen.Id("alias"))
 	}
 	dict[jen.Id("alias")] = jen.Id("alias")
-	method := jen.Func().Id("As").Params(jen.Id("alias").String()).Id(aliasedEntityName).Block(
-		jen.Return(jen.Id(aliasedEntityName).Values(dict)),
+	method := jen.Func().Id("As").Params(jen.Id("alias").String()).Id(tableTypeName).Block(
+		jen.Return(jen.Id(tableTypeName).Values(dict)),
 	)
-	stringMethod := jen.Func().Params(jen.Id("e").Id(aliasedEntityName)).Id("String").Params().String().Block(
-		jen.Return(jen.Lit(entity.Table.Name).Op("+").Lit(" AS ").Op("+").Id("e").Dot("alias")),
+	tableNameMethod := jen.Func().Params(jen.Id("t").Id(tableTypeName)).Id("TableName").Params().String().Block(
+		jen.Return(jen.Id("TABLE_NAME")),
 	)
-	aliasField := jen.Id("alias").String()
-	fields = append(fields, aliasField)
-	structType = jen.Type().Id(aliasedEntityName).Struct(fields...)
-	return jen.Add(structType).Line().Add(method).Line().Add(stringMethod)
+	// Columns of a table in a schema are still qualified by the bare table name
+	var unaliased jen.Code = jen.Id("TABLE_NAME")
+	if entity.Table.Schema != "" {
+		unaliased = jen.Lit(entity.Table.Name)
+	}
+	aliasMethod := jen.Func().Params(jen.Id("t").Id(tableTypeName)).Id("Alias").Params().String().Block(
+		jen.If(jen.Id("t").Dot("alias").Op("==").Lit("")).Block(
+			jen.Return(unaliased),
+		),
+		jen.Return(jen.Id("t").Dot("alias")),
+	)
+	stringMethod := jen.Func().Params(jen.Id("t").Id(tableTypeName)).Id("String").Params().String().Block(
+		jen.If(jen.Id("t").Dot("alias").Op("==").Lit("")).Block(
+			jen.Return(g.tableRef(entity)),
+		),
+		jen.Return(g.tableRef(entity).Op("+").Lit(" AS ").Op("+").Add(g.identifierRef(jen.Id("t").Dot("alias")))),
+	)
+	markerName := g.tableMarker(entity.Name)
+	markerMethod := jen.Comment(fmt.Sprintf("%s marks %s as the %s table in join helpers.", markerName, tableTypeName, entity.Table.QualifiedName())).Line().
+		Func().Params(jen.Id(tableTypeName)).Id(markerName).Params().Block()
+	return jen.Add(method).Line().Line().Add(tableNameMethod).Line().Line().Add(aliasMethod).Line().Line().Add(stringMethod).Line().Line().Add(markerMethod)
+}
+
+// generateJoinHelpers generates Join, LeftJoin and RightJoin helpers for every relation of the entity.
+// Generated packages never import each other, so the joined table of another entity is taken as a
+// <Target>TableRef interface that only that entity's table type implements, through its marker
+// method. Targets that are not generated alongside are taken as a plain types.TableRef.
+func (g *Generator) generateJoinHelpers(entity EntityInfo) jen.Code {
+	tableTypeName := g.tableTypeName(entity)
+	columns := make(map[string]bool, len(entity.Columns))
+	for _, col := range entity.Columns {
+		columns[col.Name] = true
+	}
+	joins := []struct {
+		prefix string
+		option string
+	}{
+		{"Join", ""},
+		{"LeftJoin", "LeftJoin"},
+		{"RightJoin", "RightJoin"},
+	}
+
+	group := &jen.Statement{}
+	refs := map[string]bool{}
+	for _, rel := range entity.Relations {
+		if rel.TargetEntity == "" || rel.TargetEntity == entity.Name || refs[rel.TargetEntity] {
+			continue
+		}
+		refs[rel.TargetEntity] = true
+		targetType := rel.TargetEntity + "Table"
+		group.Comment(fmt.Sprintf("%sRef is implemented by %s, the table type of the %s package.", targetType, targetType, strings.ToLower(rel.TargetEntity)))
+		group.Line()
+		group.Type().Id(targetType+"Ref").Interface(
+			jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "TableRef"),
+			jen.Id(g.tableMarker(rel.TargetEntity)).Params(),
+		)
+		group.Line().Line()
+	}
+	for _, rel := range entity.Relations {
+		var targetType jen.Code
+		switch rel.TargetEntity {
+		case "":
+			targetType = jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "TableRef")
+		case entity.Name:
+			targetType = jen.Id(tableTypeName)
+		default:
+			targetType = jen.Id(rel.TargetEntity + "TableRef")
+		}
+
+		// from.Column = to.TargetColumn
+		var fromRef *jen.Statement
+		if columns[rel.Column] {
+			fromRef = g.columnRef(jen.Id("from").Dot(g.toGoIdentifier(rel.Column)))
+		} else {
+			fromRef = g.identifierRef(jen.Id("from").Dot("Alias").Call()).Op("+").Lit(".").Op("+").Add(g.identifierRef(jen.Lit(rel.Column)))
+		}
+		toRef := g.identifierRef(jen.Id("to").Dot("Alias").Call()).Op("+").Lit(".").Op("+").Add(g.identifierRef(jen.Lit(rel.TargetColumn)))
+		on := jen.Add(fromRef).Op("+").Lit(" = ").Op("+").Add(toRef)
+
+		// Soft-deleted rows of the joined table are left out, as in its Select
+		params := []jen.Code{
+			jen.Id("sb").Op("*").Qual("github.com/huandu/go-sqlbuilder", "SelectBuilder"),
+			jen.Id("from").Id(tableTypeName),
+			jen.Id("to").Add(targetType),
+		}
+		var filter []jen.Code
+		if rel.TargetDelete != "" {
+			deletedAt := g.identifierRef(jen.Id("to").Dot("Alias").Call()).Op("+").Lit(".").Op("+").Add(g.identifierRef(jen.Lit(rel.TargetDelete)))
+			params = append(params, jen.Id("opts").Op("...").Qual("github.com/golshani-mhd/grizzle-kit/types", "SelectOption"))
+			filter = append(filter,
+				jen.Id("on").Op(":=").Add(on),
+				jen.Switch(jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "NewSelectOptions").Call(jen.Id("opts").Op("...")).Dot("Deleted")).Block(
+					jen.Case(jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "ExcludeDeleted")).Block(
+						jen.Id("on").Op("+=").Lit(" AND ").Op("+").Add(deletedAt.Clone()).Op("+").Lit(" IS NULL"),
+					),
+					jen.Case(jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "OnlyDeletedRows")).Block(
+						jen.Id("on").Op("+=").Lit(" AND ").Op("+").Add(deletedAt.Clone()).Op("+").Lit(" IS NOT NULL"),
+					),
+				),
+			)
+			on = jen.Id("on")
+		}
+
+		for _, join := range joins {
+			funcName := join.prefix + rel.Name
+			body := append([]jen.Code{}, filter...)
+			if join.option == "" {
+				body = append(body, jen.Return(jen.Id("sb").Dot("Join").Call(jen.Id("to").Dot("String").Call(), on.Clone())))
+			} else {
+				body = append(body, jen.Return(jen.Id("sb").Dot("JoinWithOption").Call(jen.Qual("github.com/huandu/go-sqlbuilder", join.option), jen.Id("to").Dot("String").Call(), on.Clone())))
+			}
+			group.Comment(fmt.Sprintf("%s joins %s on %s.%s = %s.%s.", funcName, rel.TargetTable, entity.Table.Name, rel.Column, rel.TargetTable, rel.TargetColumn))
+			if rel.TargetDelete != "" {
+				group.Line()
+				group.Comment("Soft-deleted " + rel.TargetTable + " rows are left out unless types.WithDeleted or types.OnlyDeleted is given.")
+			}
+			group.Line()
+			group.Func().Id(funcName).Params(params...).Op("*").Qual("github.com/huandu/go-sqlbuilder", "SelectBuilder").Block(body...)
+			group.Line().Line()
+		}
+	}
+	return group
+}
+
+// tableMarker returns the name of the marker method that sets an entity's table type apart from
+// other tables in join helpers, e.g. IsUserTable.
+func (g *Generator) tableMarker(entityName string) string {
+	return "Is" + entityName + "Table"
+}
+
+// tableTypeName returns the name of the generated struct type shared by Schema and As.
+func (g *Generator) tableTypeName(entity EntityInfo) string {
+	return entity.Name + "Table"
 }
 
 func (g *Generator) generateDefaultValue(value interface{}, goType string) jen.Code {
@@ -425,7 +1436,10 @@ func (g *Generator) generateDefaultValue(value interface{}, goType string) jen.C
 		}
 		return jen.Lit("")
 	case "int8", "int16", "int32", "int64":
-		if val, ok := value.(int64); ok {
+		switch val := value.(type) {
+		case int:
+			return jen.Lit(val)
+		case int64:
 			return jen.Lit(val)
 		}
 		return jen.Lit(0)
@@ -450,6 +1464,14 @@ func (g *Generator) generateDefaultValue(value interface{}, goType string) jen.C
 		}
 		return jen.Lit([]byte{})
 	case "time.Time":
+		if t, ok := value.(time.Time); ok {
+			t = t.UTC()
+			return jen.Qual("time", "Date").Call(
+				jen.Lit(t.Year()), jen.Qual("time", t.Month().String()), jen.Lit(t.Day()),
+				jen.Lit(t.Hour()), jen.Lit(t.Minute()), jen.Lit(t.Second()), jen.Lit(t.Nanosecond()),
+				jen.Qual("time", "UTC"),
+			)
+		}
 		return jen.Qual("time", "Time").Values()
 	default:
 		return jen.Nil()
@@ -457,11 +1479,11 @@ func (g *Generator) generateDefaultValue(value interface{}, goType string) jen.C
 }
 
 func (g *Generator) generateModelFile(entity EntityInfo) error {
-	// Get the base output directory (remove entity-specific subdirectory)
-	baseDir := g.config.OutputDir
-
-	// Create model directory alongside the entity directories
-	modelDir := filepath.Join(baseDir, "..", "model")
+	// Create model directory alongside the entity directories, unless configured otherwise
+	modelDir := g.config.ModelDir
+	if modelDir == "" {
+		modelDir = filepath.Join(g.config.OutputDir, "..", "model")
+	}
 	if err := os.MkdirAll(modelDir, 0755); err != nil {
 		return fmt.Errorf("failed to create model directory %s: %w", modelDir, err)
 	}
@@ -474,6 +1496,14 @@ func (g *Generator) generateModelFile(entity EntityInfo) error {
 	// Generate the struct
 	file.Add(g.generateModelStruct(entity))
 
+	// Generate a named type for each enum column
+	for _, col := range entity.Columns {
+		if g.isEnumColumn(col) {
+			file.Line()
+			file.Add(g.generateEnumType(entity, col))
+		}
+	}
+
 	// Save the file
 	fileName := strings.ToLower(entity.Name) + ".go"
 	filePath := filepath.Join(modelDir, fileName)
@@ -486,52 +1516,78 @@ func (g *Generator) generateModelStruct(entity EntityInfo) jen.Code {
 
 	for _, col := range entity.Columns {
 		fieldName := g.toGoIdentifier(col.Name)
-		fieldType := g.getJenType(col.GoType)
+		fieldType := g.goTypeCode(col.GoType)
+		if g.isEnumColumn(col) {
+			fieldType = jen.Id(g.enumTypeName(entity, col))
+		} else if scanner := g.collectionScanner(col); scanner != nil {
+			fieldType = scanner
+		}
+		// Nullable columns scan NULL into a nil pointer
+		if col.Nullable {
+			fieldType = jen.Op("*").Add(fieldType)
+		}
 
-		// Add struct tag with column name
-		field := jen.Id(fieldName).Add(fieldType).Tag(map[string]string{
-			"db": col.Name,
-		})
+		// Add struct tag with column name; generated columns are tagged read-only so that
+		// sqlbuilder.Struct(...).WithoutTag("readonly") leaves them out of writes
+		tags := map[string]string{"db": col.Name}
+		if col.Comment != "" {
+			fields = append(fields, jen.Comment(col.Comment))
+		}
+		field := jen.Id(fieldName).Add(fieldType)
+		if col.Generated != nil {
+			tags["fieldtag"] = "readonly"
+			field.Tag(tags).Comment("read-only: generated as " + col.Generated.Expr)
+		} else {
+			field.Tag(tags)
+		}
 		fields = append(fields, field)
 	}
 
 	// Generate the struct type
 	structName := entity.Name
-	return jen.Type().Id(structName).Struct(fields...)
+	return g.tableComment(entity).Type().Id(structName).Struct(fields...)
 }
 
-func (g *Generator) getJenType(goType string) jen.Code {
+// goTypeCode converts a Go type expression such as "int32", "[]byte", "map[string]int64" or
+// "github.com/shopspring/decimal.Decimal" into jen code, importing the package of qualified types.
+func (g *Generator) goTypeCode(goType string) *jen.Statement {
+	switch {
+	case goType == "" || goType == "interface{}" || goType == "any":
+		return jen.Interface()
+	case strings.HasPrefix(goType, "[]"):
+		return jen.Index().Add(g.goTypeCode(goType[2:]))
+	case strings.HasPrefix(goType, "*"):
+		return jen.Op("*").Add(g.goTypeCode(goType[1:]))
+	}
+	if key, value, ok := splitMapType(goType); ok {
+		return jen.Map(g.goTypeCode(key)).Add(g.goTypeCode(value))
+	}
+	if dot := strings.LastIndex(goType, "."); dot > 0 && dot > strings.LastIndex(goType, "/") {
+		return jen.Qual(goType[:dot], goType[dot+1:])
+	}
+	return jen.Id(goType)
+}
+
+// typeOverride returns the Go type configured in type_overrides for an abstract column type, if any.
+// Keys are matched case-insensitively against the type name, e.g. "decimal" for ColumnTypeDecimal.
+func (g *Generator) typeOverride(abstractType string) (string, bool) {
+	key := strings.ToLower(strings.TrimPrefix(abstractType, "ColumnType"))
+	for name, goType := range g.config.TypeOverrides {
+		if strings.ToLower(name) == key {
+			return goType, true
+		}
+	}
+	return "", false
+}
+
+// isBuiltinGoType reports whether goType is one of the types the generator knows how to write literals for.
+func isBuiltinGoType(goType string) bool {
 	switch goType {
-	case "string":
-		return jen.String()
-	case "int8":
-		return jen.Int8()
-	case "int16":
-		return jen.Int16()
-	case "int32":
-		return jen.Int32()
-	case "int64":
-		return jen.Int64()
-	case "uint8":
-		return jen.Uint8()
-	case "uint16":
-		return jen.Uint16()
-	case "uint32":
-		return jen.Uint32()
-	case "uint64":
-		return jen.Uint64()
-	case "bool":
-		return jen.Bool()
-	case "float32":
-		return jen.Float32()
-	case "float64":
-		return jen.Float64()
-	case "[]byte":
-		return jen.Index().Byte()
-	case "time.Time":
-		return jen.Qual("time", "Time")
+	case "string", "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "bool", "[]byte", "time.Time":
+		return true
 	default:
-		return jen.Interface()
+		return false
 	}
 }
 
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const relationsSchema = `package schema
+
+import "github.com/golshani-mhd/grizzle-kit/types"
+
+var UserSchema = types.Table{
+	Name: "users",
+	Columns: []*types.Column[any]{
+		types.BigInt("id"),
+	},
+}
+
+var PostSchema = types.Table{
+	Name: "posts",
+	Columns: []*types.Column[any]{
+		types.BigInt("id"),
+		types.BigInt("author", types.WithReferences[int64]("users", "id")),
+		types.BigInt("editor", types.WithReferences[int64]("users", "id")),
+		types.BigInt("reviewer_id", types.WithReferences[int64]("users", "id")),
+	},
+}
+`
+
+func TestRelationsToTheSameTable(t *testing.T) {
+	dir := t.TempDir()
+	schemaFile := filepath.Join(dir, "schema.go")
+	if err := os.WriteFile(schemaFile, []byte(relationsSchema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gen := NewGenerator(&GeneratorConfig{OutputDir: filepath.Join(dir, "gen", "schema")})
+	entities, err := gen.ParseFiles(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.GenerateEntities(entities); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file  string
+		funcs []string
+	}{
+		{"post/post.go", []string{"JoinUsersByAuthor", "LeftJoinUsersByEditor", "RightJoinReviewer"}},
+		{"user/user.go", []string{"JoinPostsByAuthor", "JoinPostsByEditor", "JoinPostsByReviewerId"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			node, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, "gen", "schema", tt.file), nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			declared := map[string]int{}
+			for _, decl := range node.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+					declared[fn.Name.Name]++
+				}
+			}
+			for name, count := range declared {
+				if count > 1 {
+					t.Errorf("%s declared %d times", name, count)
+				}
+			}
+			for _, name := range tt.funcs {
+				if declared[name] == 0 {
+					t.Errorf("%s not generated", name)
+				}
+			}
+		})
+	}
+}
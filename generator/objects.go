@@ -0,0 +1,256 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/golshani-mhd/grizzle-kit/types"
+)
+
+// domainInfo is a types.Domain declared next to the tables, resolved by Domain.Column calls
+type domainInfo struct {
+	types.Domain
+	AbstractType string // Name of the types constant of the base type, e.g. ColumnTypeVarchar
+}
+
+// extractObjects records the extensions, sequences and domains declared in the file. Sequences and
+// domains are keyed by variable name, so that columns can refer to them through Sequence.NextVal
+// and Domain.Column, and every object is kept in declaration order for the SQL output. declared
+// holds the file of each object recorded so far; a variable name declared twice is an error, as a
+// reference to it could not be resolved
+func (g *Generator) extractObjects(node *ast.File, pkgAlias, filePath string, declared map[string]string) error {
+	if pkgAlias == "" {
+		return nil
+	}
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range valueSpec.Names {
+				if i >= len(valueSpec.Values) {
+					break
+				}
+				value := valueSpec.Values[i]
+				if unary, ok := value.(*ast.UnaryExpr); ok {
+					value = unary.X
+				}
+				lit, ok := value.(*ast.CompositeLit)
+				if !ok {
+					continue
+				}
+				selector, ok := lit.Type.(*ast.SelectorExpr)
+				if !ok {
+					continue
+				}
+				if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != pkgAlias {
+					continue
+				}
+				switch selector.Sel.Name {
+				case "Extension", "Sequence", "Domain":
+					if file, ok := declared[name.Name]; ok {
+						return fmt.Errorf("%s is declared in both %s and %s", name.Name, file, filePath)
+					}
+					declared[name.Name] = filePath
+				}
+				switch selector.Sel.Name {
+				case "Extension":
+					g.objects = append(g.objects, g.parseExtension(lit))
+				case "Sequence":
+					sequence := g.parseSequence(lit)
+					g.sequences[name.Name] = sequence
+					g.objects = append(g.objects, sequence)
+				case "Domain":
+					domain, err := g.parseDomain(lit, pkgAlias)
+					if err != nil {
+						return fmt.Errorf("failed to parse domain %s in %s: %w", name.Name, filePath, err)
+					}
+					g.domains[name.Name] = domain
+					g.objects = append(g.objects, domain.Domain)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// extractConstants records the values of the constants declared in the file, so that defaults
+// such as WithDefault(DefaultStatus) can be written as literals. A name declared in several
+// packages is recorded as nil, so that defaults using it are reported as unresolved
+func (g *Generator) extractConstants(node *ast.File) {
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range valueSpec.Names {
+				if _, ok := g.constants[name.Name]; ok {
+					g.constants[name.Name] = nil
+				} else if i < len(valueSpec.Values) {
+					g.constants[name.Name] = valueSpec.Values[i]
+				}
+			}
+		}
+	}
+}
+
+// objectFields returns the key-value fields of an object literal by name
+func (g *Generator) objectFields(lit *ast.CompositeLit) map[string]ast.Expr {
+	fields := map[string]ast.Expr{}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				fields[key.Name] = kv.Value
+			}
+		}
+	}
+	return fields
+}
+
+// parseExtension parses a types.Extension literal, e.g. types.Extension{Name: "pgcrypto"}
+func (g *Generator) parseExtension(lit *ast.CompositeLit) types.Extension {
+	var extension types.Extension
+	for key, value := range g.objectFields(lit) {
+		switch key {
+		case "Name":
+			extension.Name, _ = g.evalString(value, "")
+		case "Schema":
+			extension.Schema, _ = g.evalString(value, "")
+		}
+	}
+	return extension
+}
+
+// parseSequence parses a types.Sequence literal, e.g. types.Sequence{Name: "order_numbers", Start: types.Ptr[int64](1000)}
+func (g *Generator) parseSequence(lit *ast.CompositeLit) types.Sequence {
+	var sequence types.Sequence
+	for key, value := range g.objectFields(lit) {
+		switch key {
+		case "Name":
+			sequence.Name, _ = g.evalString(value, "")
+		case "Schema":
+			sequence.Schema, _ = g.evalString(value, "")
+		case "OwnedBy":
+			sequence.OwnedBy, _ = g.evalString(value, "")
+		case "Start":
+			sequence.Start = g.parseInt64Ptr(value)
+		case "Increment":
+			sequence.Increment = g.parseInt64Ptr(value)
+		case "MinValue":
+			sequence.MinValue = g.parseInt64Ptr(value)
+		case "MaxValue":
+			sequence.MaxValue = g.parseInt64Ptr(value)
+		case "Cache":
+			sequence.Cache = g.parseInt64Ptr(value)
+		case "Cycle":
+			sequence.Cycle = isTrue(value)
+		}
+	}
+	return sequence
+}
+
+// parseDomain parses a types.Domain literal, e.g. types.Domain{Name: "email", Type: types.ColumnTypeText}
+func (g *Generator) parseDomain(lit *ast.CompositeLit, pkgAlias string) (domainInfo, error) {
+	var domain domainInfo
+	for key, value := range g.objectFields(lit) {
+		switch key {
+		case "Name":
+			domain.Name, _ = g.evalString(value, "")
+		case "Schema":
+			domain.Schema, _ = g.evalString(value, "")
+		case "Check":
+			domain.Check, _ = g.evalString(value, "")
+		case "Type":
+			if selector, ok := value.(*ast.SelectorExpr); ok {
+				domain.AbstractType = selector.Sel.Name
+			}
+		case "Length":
+			domain.Length = g.parseIntPtr(value)
+		case "Precision":
+			domain.Precision = g.parseIntPtr(value)
+		case "Scale":
+			domain.Scale = g.parseIntPtr(value)
+		case "NotNull":
+			domain.NotNull = isTrue(value)
+		case "Default":
+			// Written as types.Ptr(types.SQL("...")) or &expr
+			if call, ok := value.(*ast.CallExpr); ok && len(call.Args) == 1 {
+				value = call.Args[0]
+			} else if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+				value = unary.X
+			}
+			if domain.Default = g.parseDefaultExpr(value, pkgAlias); domain.Default == nil {
+				return domain, fmt.Errorf("default is not a types expression")
+			}
+		}
+	}
+	columnType, ok := columnTypes[domain.AbstractType]
+	if !ok {
+		return domain, fmt.Errorf("unknown type %s", domain.AbstractType)
+	}
+	domain.Type = columnType
+	return domain, nil
+}
+
+// parseIntPtr parses a *int written as types.Ptr(n)
+func (g *Generator) parseIntPtr(expr ast.Expr) *int {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+	if value, ok := g.evalDefault(call.Args[0], 0); ok {
+		if v, ok := value.(int); ok {
+			return &v
+		}
+	}
+	return nil
+}
+
+// parseInt64Ptr parses a *int64 written as types.Ptr[int64](n) or types.Ptr(int64(n))
+func (g *Generator) parseInt64Ptr(expr ast.Expr) *int64 {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+	arg := call.Args[0]
+	if conversion, ok := arg.(*ast.CallExpr); ok && len(conversion.Args) == 1 {
+		arg = conversion.Args[0]
+	}
+	if value, ok := g.evalDefault(arg, 0); ok {
+		if v, ok := value.(int); ok {
+			v64 := int64(v)
+			return &v64
+		}
+	}
+	return nil
+}
+
+// isTrue reports whether a bool field is set to true
+func isTrue(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "true"
+}
+
+// objectRef returns the variable name of an object referenced as Name or pkg.Name
+func objectRef(expr ast.Expr) (string, bool) {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x.Name, true
+	case *ast.SelectorExpr:
+		if _, ok := x.X.(*ast.Ident); ok {
+			return x.Sel.Name, true
+		}
+	}
+	return "", false
+}
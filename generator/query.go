@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"github.com/golshani-mhd/grizzle-kit/types"
+)
+
+// generateQueryHelpers generates Select, Insert, Update and Delete helpers returning go-sqlbuilder
+// builders preset for the entity's table. Generated columns are never written, and on soft-delete
+// tables Delete becomes an UPDATE of the soft-delete column next to a HardDelete helper.
+func (g *Generator) generateQueryHelpers(entity EntityInfo) jen.Code {
+	var selectCols []jen.Code
+	var insertCols []jen.Code
+	for _, col := range entity.Columns {
+		selectCols = append(selectCols, jen.Id(g.toGoIdentifier(col.Name)))
+		if col.Generated == nil {
+			insertCols = append(insertCols, jen.Lit(g.quoteIdentifier(col.Name)))
+		}
+	}
+	table := g.tableRef(entity)
+	group := &jen.Statement{}
+
+	softDelete := g.softDeleteColumn(entity)
+	selectBody := []jen.Code{
+		jen.Id("sb").Op(":=").Qual("github.com/huandu/go-sqlbuilder", "NewSelectBuilder").Call(),
+		jen.Id("sb").Dot("Select").Call(selectCols...),
+		jen.Id("sb").Dot("From").Call(jen.Id("Schema").Dot("String").Call()),
+	}
+	var selectParams []jen.Code
+	if softDelete != nil {
+		deletedAt := jen.Id(g.toGoIdentifier(softDelete.Name))
+		selectParams = append(selectParams, jen.Id("opts").Op("...").Qual("github.com/golshani-mhd/grizzle-kit/types", "SelectOption"))
+		selectBody = append(selectBody, jen.Switch(jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "NewSelectOptions").Call(jen.Id("opts").Op("...")).Dot("Deleted")).Block(
+			jen.Case(jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "ExcludeDeleted")).Block(
+				jen.Id("sb").Dot("Where").Call(jen.Id("sb").Dot("IsNull").Call(deletedAt.Clone())),
+			),
+			jen.Case(jen.Qual("github.com/golshani-mhd/grizzle-kit/types", "OnlyDeletedRows")).Block(
+				jen.Id("sb").Dot("Where").Call(jen.Id("sb").Dot("IsNotNull").Call(deletedAt.Clone())),
+			),
+		))
+	}
+	selectBody = append(selectBody, jen.Return(jen.Id("sb")))
+
+	group.Comment("Select starts a SELECT of every column from " + entity.Table.Name + ".")
+	if softDelete != nil {
+		group.Line()
+		group.Comment("Soft-deleted rows are left out unless types.WithDeleted or types.OnlyDeleted is given.")
+	}
+	group.Line()
+	group.Func().Id("Select").Params(selectParams...).Op("*").Qual("github.com/huandu/go-sqlbuilder", "SelectBuilder").Block(selectBody...)
+	group.Line().Line()
+
+	group.Comment("InsertColumns lists the columns written by Insert; generated columns are excluded.")
+	group.Line()
+	group.Var().Id("InsertColumns").Op("=").Index().String().Values(insertCols...)
+	group.Line().Line()
+
+	group.Comment("Insert starts an INSERT into " + entity.Table.Name + " over InsertColumns; add rows with Values.")
+	group.Line()
+	group.Func().Id("Insert").Params().Op("*").Qual("github.com/huandu/go-sqlbuilder", "InsertBuilder").Block(
+		jen.Id("ib").Op(":=").Qual("github.com/huandu/go-sqlbuilder", "NewInsertBuilder").Call(),
+		jen.Id("ib").Dot("InsertInto").Call(table.Clone()),
+		jen.Id("ib").Dot("Cols").Call(jen.Id("InsertColumns").Op("...")),
+		jen.Return(jen.Id("ib")),
+	)
+	group.Line().Line()
+
+	// Columns kept current by the database need no help from Update
+	var touched []string
+	for _, col := range entity.Columns {
+		if col.OnUpdateNow {
+			touched = append(touched, col.Name)
+		}
+	}
+	if flavor, ok := g.flavor(); ok && types.HasNativeOnUpdate(flavor) {
+		touched = nil
+	}
+	loop := []jen.Code{
+		jen.If(jen.Id("a").Dot("ReadOnly")).Block(jen.Continue()),
+		jen.Id("ub").Dot("SetMore").Call(jen.Id("ub").Dot("Assign").Call(g.identifierRef(jen.Id("a").Dot("Column")), jen.Id("a").Dot("Value"))),
+	}
+	body := []jen.Code{
+		jen.Id("ub").Op(":=").Qual("github.com/huandu/go-sqlbuilder", "NewUpdateBuilder").Call(),
+		jen.Id("ub").Dot("Update").Call(table.Clone()),
+	}
+	if len(touched) > 0 {
+		body = append(body, jen.Id("assigned").Op(":=").Map(jen.String()).Bool().Values())
+		loop = append(loop, jen.Id("assigned").Index(jen.Id("a").Dot("Column")).Op("=").True())
+	}
+	body = append(body, jen.For(jen.List(jen.Id("_"), jen.Id("a")).Op(":=").Range().Id("assignments")).Block(loop...))
+	for _, name := range touched {
+		body = append(body, jen.If(jen.Op("!").Id("assigned").Index(jen.Lit(name))).Block(
+			jen.Id("ub").Dot("SetMore").Call(jen.Id("ub").Dot("Assign").Call(jen.Lit(g.quoteIdentifier(name)), g.nowRef())),
+		))
+	}
+	body = append(body, jen.Return(jen.Id("ub")))
+
+	group.Comment("Update starts an UPDATE of " + entity.Table.Name + " with the given assignments, e.g. Schema.Name.Set(\"x\").")
+	group.Line()
+	note := "Assignments to generated columns are dropped."
+	if len(touched) > 0 {
+		note += " Unless assigned, " + strings.Join(touched, ", ") + " is set to the current time."
+	}
+	group.Comment(note)
+	group.Line()
+	group.Func().Id("Update").Params(jen.Id("assignments").Op("...").Qual("github.com/golshani-mhd/grizzle-kit/types", "Assignment")).Op("*").Qual("github.com/huandu/go-sqlbuilder", "UpdateBuilder").Block(body...)
+	group.Line().Line()
+
+	deleteName := "Delete"
+	if softDelete != nil {
+		deletedAt := g.quoteIdentifier(softDelete.Name)
+		group.Comment("Delete soft-deletes rows of " + entity.Table.Name + " by setting " + softDelete.Name + " to the current time.")
+		group.Line()
+		group.Comment("Rows already deleted keep their deletion time; use HardDelete to remove rows.")
+		group.Line()
+		group.Func().Id("Delete").Params().Op("*").Qual("github.com/huandu/go-sqlbuilder", "UpdateBuilder").Block(
+			jen.Id("ub").Op(":=").Qual("github.com/huandu/go-sqlbuilder", "NewUpdateBuilder").Call(),
+			jen.Id("ub").Dot("Update").Call(table.Clone()),
+			jen.Id("ub").Dot("Set").Call(jen.Id("ub").Dot("Assign").Call(jen.Lit(deletedAt), g.nowRef())),
+			jen.Id("ub").Dot("Where").Call(jen.Id("ub").Dot("IsNull").Call(jen.Lit(deletedAt))),
+			jen.Return(jen.Id("ub")),
+		)
+		group.Line().Line()
+		deleteName = "HardDelete"
+	}
+
+	group.Comment(deleteName + " starts a DELETE from " + entity.Table.Name + ".")
+	group.Line()
+	group.Func().Id(deleteName).Params().Op("*").Qual("github.com/huandu/go-sqlbuilder", "DeleteBuilder").Block(
+		jen.Id("db").Op(":=").Qual("github.com/huandu/go-sqlbuilder", "NewDeleteBuilder").Call(),
+		jen.Id("db").Dot("DeleteFrom").Call(table.Clone()),
+		jen.Return(jen.Id("db")),
+	)
+	group.Line()
+	return group
+}
+
+// nowRef renders the database's current timestamp as a raw go-sqlbuilder value, so that columns
+// of any Go type, including type overrides, are set by the database clock.
+func (g *Generator) nowRef() *jen.Statement {
+	now := "CURRENT_TIMESTAMP"
+	if flavor, ok := g.flavor(); ok {
+		now = types.Now().SQL(flavor)
+	}
+	return jen.Qual("github.com/huandu/go-sqlbuilder", "Raw").Call(jen.Lit(now))
+}
+
+// softDeleteColumn returns the soft-delete column of the entity's table, or nil when rows are deleted outright.
+func (g *Generator) softDeleteColumn(entity EntityInfo) *ColumnInfo {
+	if entity.Table.SoftDelete == "" {
+		return nil
+	}
+	for i := range entity.Columns {
+		if entity.Columns[i].Name == entity.Table.SoftDelete {
+			return &entity.Columns[i]
+		}
+	}
+	return nil
+}
+
+// quoteIdentifier quotes an identifier at generation time for the configured flavor, if any.
+func (g *Generator) quoteIdentifier(name string) string {
+	if flavor, ok := g.flavor(); ok {
+		return flavor.Quote(name)
+	}
+	return name
+}
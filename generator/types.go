@@ -1,40 +1,75 @@
 package generator
 
 import (
+	"go/ast"
+
 	"github.com/golshani-mhd/grizzle-kit/types"
 )
 
 // EntityInfo represents information about an entity to be generated
 type EntityInfo struct {
-	Name    string
-	Table   *types.Table
-	Columns []ColumnInfo
+	Name      string
+	Table     *types.Table
+	Columns   []ColumnInfo
+	Relations []RelationInfo
+}
+
+// RelationInfo represents a join path derived from a foreign key, seen from one of its tables
+type RelationInfo struct {
+	Name         string // Suffix of the generated Join helpers
+	Column       string // Column of this entity's table
+	TargetTable  string
+	TargetColumn string
+	TargetEntity string // Entity of the target table, empty when it is not generated with this one
+	TargetDelete string // Soft-delete column of the target table, if any
 }
 
 // ColumnInfo represents information about a column
 type ColumnInfo struct {
-	Name          string
-	GoType        string
-	SQLType       string
-	AbstractType  string
-	AutoIncrement bool
-	HasDefault    bool
-	DefaultValue  interface{}
-	Length        *int
-	Precision     *int
-	Scale         *int
+	Name           string
+	GoType         string
+	SQLType        string // SQL type set with WithType, empty for the type of AbstractType
+	UnresolvedType string // Go source of a WithType argument the generator could not evaluate
+	AbstractType   string
+	AutoIncrement  bool
+	HasDefault     bool
+	DefaultValue   interface{}
+	DefaultExpr    *types.Expr
+	Length         *int
+	Precision      *int
+	Scale          *int
+	References     *types.ForeignKey
+	EnumValues     []string
+	Check          string
+	Generated      *types.Generated
+	OnUpdateNow    bool
+	Comment        string
+	LowCardinality bool
+	Nullable       bool
+	ElementType    string // types constant of collection elements, e.g. "ColumnTypeText"
+	KeyType        string // types constant of map keys
+	Domain         string // PostgreSQL domain of the column
 }
 
 // GeneratorConfig holds configuration for the generator
 type GeneratorConfig struct {
 	OutputDir   string
+	ModelDir    string // Directory of the model package, model next to OutputDir by default
 	PackageName string
 	Flavor      string
-	Verbose     bool
-	Recursive   bool
+	SQLOut      string // File (schema.sql) or directory (one file per table) CREATE statements are written to
+	// TypeOverrides maps abstract column types (e.g. "decimal", "uuid") to Go types
+	// (e.g. "github.com/shopspring/decimal.Decimal") used in generated code
+	TypeOverrides map[string]string
+	Verbose       bool
+	Recursive     bool
 }
 
 // Generator handles code generation for Grizzle entities
 type Generator struct {
-	config *GeneratorConfig
+	config    *GeneratorConfig
+	constants map[string]ast.Expr       // Values of constants declared in the schema files, by name
+	sequences map[string]types.Sequence // Sequences by variable name
+	domains   map[string]domainInfo     // Domains by variable name
+	objects   []types.SchemaObject      // Extensions, sequences and domains in declaration order
 }
@@ -2,6 +2,7 @@ package mapping
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -48,6 +49,9 @@ const (
 	Presto
 	Oracle
 	Informix
+	MariaDB
+	CockroachDB
+	DuckDB
 )
 
 func (f Flavor) String() string {
@@ -70,6 +74,12 @@ func (f Flavor) String() string {
 		return "Oracle"
 	case Informix:
 		return "Informix"
+	case MariaDB:
+		return "MariaDB"
+	case CockroachDB:
+		return "CockroachDB"
+	case DuckDB:
+		return "DuckDB"
 	default:
 		return "Unknown"
 	}
@@ -92,15 +102,306 @@ type Column[T any] struct {
 // typeMappings maps flavors to abstract column types to base SQL type strings.
 // Parameters like length, precision are appended in getSQLType.
 var typeMappings = map[Flavor]map[ColumnType]string{
-	MySQL:      { /* full mapping copied from internal */ },
-	PostgreSQL: {},
-	SQLite:     {},
-	SQLServer:  {},
-	CQL:        {},
-	ClickHouse: {},
-	Presto:     {},
-	Oracle:     {},
-	Informix:   {},
+	MySQL: {
+		ColumnTypeVarchar:   "VARCHAR",
+		ColumnTypeChar:      "CHAR",
+		ColumnTypeText:      "TEXT",
+		ColumnTypeTinyInt:   "TINYINT",
+		ColumnTypeSmallInt:  "SMALLINT",
+		ColumnTypeInt:       "INT",
+		ColumnTypeBigInt:    "BIGINT",
+		ColumnTypeBoolean:   "BOOLEAN",
+		ColumnTypeReal:      "FLOAT",
+		ColumnTypeDouble:    "DOUBLE",
+		ColumnTypeDecimal:   "DECIMAL",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIME",
+		ColumnTypeDateTime:  "DATETIME",
+		ColumnTypeTimestamp: "TIMESTAMP",
+		ColumnTypeBlob:      "BLOB",
+		ColumnTypeJson:      "JSON",
+		ColumnTypeUuid:      "CHAR(36)",
+		ColumnTypeBit:       "BIT",
+		ColumnTypeBinary:    "BINARY",
+		ColumnTypeVarbinary: "VARBINARY",
+		ColumnTypeMoney:     "DECIMAL",
+		ColumnTypeXml:       "TEXT",
+	},
+	PostgreSQL: {
+		ColumnTypeVarchar:   "VARCHAR",
+		ColumnTypeChar:      "CHAR",
+		ColumnTypeText:      "TEXT",
+		ColumnTypeTinyInt:   "SMALLINT",
+		ColumnTypeSmallInt:  "SMALLINT",
+		ColumnTypeInt:       "INTEGER",
+		ColumnTypeBigInt:    "BIGINT",
+		ColumnTypeBoolean:   "BOOLEAN",
+		ColumnTypeReal:      "REAL",
+		ColumnTypeDouble:    "DOUBLE PRECISION",
+		ColumnTypeDecimal:   "NUMERIC",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIME",
+		ColumnTypeDateTime:  "TIMESTAMP",
+		ColumnTypeTimestamp: "TIMESTAMPTZ",
+		ColumnTypeBlob:      "BYTEA",
+		ColumnTypeJson:      "JSONB",
+		ColumnTypeUuid:      "UUID",
+		ColumnTypeBit:       "BIT",
+		ColumnTypeBinary:    "BYTEA",
+		ColumnTypeVarbinary: "BYTEA",
+		ColumnTypeMoney:     "MONEY",
+		ColumnTypeXml:       "XML",
+	},
+	SQLite: {
+		ColumnTypeVarchar:   "TEXT",
+		ColumnTypeChar:      "TEXT",
+		ColumnTypeText:      "TEXT",
+		ColumnTypeTinyInt:   "INTEGER",
+		ColumnTypeSmallInt:  "INTEGER",
+		ColumnTypeInt:       "INTEGER",
+		ColumnTypeBigInt:    "INTEGER",
+		ColumnTypeBoolean:   "INTEGER",
+		ColumnTypeReal:      "REAL",
+		ColumnTypeDouble:    "REAL",
+		ColumnTypeDecimal:   "NUMERIC",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIME",
+		ColumnTypeDateTime:  "DATETIME",
+		ColumnTypeTimestamp: "TIMESTAMP",
+		ColumnTypeBlob:      "BLOB",
+		ColumnTypeJson:      "TEXT",
+		ColumnTypeUuid:      "TEXT",
+		ColumnTypeBit:       "INTEGER",
+		ColumnTypeBinary:    "BLOB",
+		ColumnTypeVarbinary: "BLOB",
+		ColumnTypeMoney:     "NUMERIC",
+		ColumnTypeXml:       "TEXT",
+	},
+	SQLServer: {
+		ColumnTypeVarchar:   "NVARCHAR",
+		ColumnTypeChar:      "NCHAR",
+		ColumnTypeText:      "NVARCHAR(MAX)",
+		ColumnTypeTinyInt:   "TINYINT",
+		ColumnTypeSmallInt:  "SMALLINT",
+		ColumnTypeInt:       "INT",
+		ColumnTypeBigInt:    "BIGINT",
+		ColumnTypeBoolean:   "BIT",
+		ColumnTypeReal:      "REAL",
+		ColumnTypeDouble:    "FLOAT",
+		ColumnTypeDecimal:   "DECIMAL",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIME",
+		ColumnTypeDateTime:  "DATETIME2",
+		ColumnTypeTimestamp: "DATETIMEOFFSET",
+		ColumnTypeBlob:      "VARBINARY(MAX)",
+		ColumnTypeJson:      "NVARCHAR(MAX)",
+		ColumnTypeUuid:      "UNIQUEIDENTIFIER",
+		ColumnTypeBit:       "BIT",
+		ColumnTypeBinary:    "BINARY",
+		ColumnTypeVarbinary: "VARBINARY",
+		ColumnTypeMoney:     "MONEY",
+		ColumnTypeXml:       "XML",
+	},
+	CQL: {
+		ColumnTypeVarchar:   "varchar",
+		ColumnTypeChar:      "text",
+		ColumnTypeText:      "text",
+		ColumnTypeTinyInt:   "tinyint",
+		ColumnTypeSmallInt:  "smallint",
+		ColumnTypeInt:       "int",
+		ColumnTypeBigInt:    "bigint",
+		ColumnTypeBoolean:   "boolean",
+		ColumnTypeReal:      "float",
+		ColumnTypeDouble:    "double",
+		ColumnTypeDecimal:   "decimal",
+		ColumnTypeDate:      "date",
+		ColumnTypeTime:      "time",
+		ColumnTypeDateTime:  "timestamp",
+		ColumnTypeTimestamp: "timestamp",
+		ColumnTypeBlob:      "blob",
+		ColumnTypeJson:      "text",
+		ColumnTypeUuid:      "uuid",
+		ColumnTypeBit:       "boolean",
+		ColumnTypeBinary:    "blob",
+		ColumnTypeVarbinary: "blob",
+		ColumnTypeMoney:     "decimal",
+		ColumnTypeXml:       "text",
+	},
+	ClickHouse: {
+		ColumnTypeVarchar:   "String",
+		ColumnTypeChar:      "String",
+		ColumnTypeText:      "String",
+		ColumnTypeTinyInt:   "Int8",
+		ColumnTypeSmallInt:  "Int16",
+		ColumnTypeInt:       "Int32",
+		ColumnTypeBigInt:    "Int64",
+		ColumnTypeBoolean:   "Bool",
+		ColumnTypeReal:      "Float32",
+		ColumnTypeDouble:    "Float64",
+		ColumnTypeDecimal:   "Decimal",
+		ColumnTypeDate:      "Date",
+		ColumnTypeTime:      "String",
+		ColumnTypeDateTime:  "DateTime",
+		ColumnTypeTimestamp: "DateTime64(3)",
+		ColumnTypeBlob:      "String",
+		ColumnTypeJson:      "JSON",
+		ColumnTypeUuid:      "UUID",
+		ColumnTypeBit:       "UInt8",
+		ColumnTypeBinary:    "String",
+		ColumnTypeVarbinary: "String",
+		ColumnTypeMoney:     "Decimal",
+		ColumnTypeXml:       "String",
+	},
+	Presto: {
+		ColumnTypeVarchar:   "VARCHAR",
+		ColumnTypeChar:      "CHAR",
+		ColumnTypeText:      "VARCHAR",
+		ColumnTypeTinyInt:   "TINYINT",
+		ColumnTypeSmallInt:  "SMALLINT",
+		ColumnTypeInt:       "INTEGER",
+		ColumnTypeBigInt:    "BIGINT",
+		ColumnTypeBoolean:   "BOOLEAN",
+		ColumnTypeReal:      "REAL",
+		ColumnTypeDouble:    "DOUBLE",
+		ColumnTypeDecimal:   "DECIMAL",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIME",
+		ColumnTypeDateTime:  "TIMESTAMP",
+		ColumnTypeTimestamp: "TIMESTAMP WITH TIME ZONE",
+		ColumnTypeBlob:      "VARBINARY",
+		ColumnTypeJson:      "JSON",
+		ColumnTypeUuid:      "UUID",
+		ColumnTypeBit:       "BOOLEAN",
+		ColumnTypeBinary:    "VARBINARY",
+		ColumnTypeVarbinary: "VARBINARY",
+		ColumnTypeMoney:     "DECIMAL",
+		ColumnTypeXml:       "VARCHAR",
+	},
+	Oracle: {
+		ColumnTypeVarchar:   "VARCHAR2",
+		ColumnTypeChar:      "CHAR",
+		ColumnTypeText:      "CLOB",
+		ColumnTypeTinyInt:   "NUMBER(3)",
+		ColumnTypeSmallInt:  "NUMBER(5)",
+		ColumnTypeInt:       "NUMBER(10)",
+		ColumnTypeBigInt:    "NUMBER(19)",
+		ColumnTypeBoolean:   "NUMBER(1)",
+		ColumnTypeReal:      "BINARY_FLOAT",
+		ColumnTypeDouble:    "BINARY_DOUBLE",
+		ColumnTypeDecimal:   "NUMBER",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIMESTAMP",
+		ColumnTypeDateTime:  "TIMESTAMP",
+		ColumnTypeTimestamp: "TIMESTAMP WITH TIME ZONE",
+		ColumnTypeBlob:      "BLOB",
+		ColumnTypeJson:      "CLOB",
+		ColumnTypeUuid:      "VARCHAR2(36)",
+		ColumnTypeBit:       "NUMBER(1)",
+		ColumnTypeBinary:    "RAW",
+		ColumnTypeVarbinary: "RAW",
+		ColumnTypeMoney:     "NUMBER",
+		ColumnTypeXml:       "XMLTYPE",
+	},
+	Informix: {
+		ColumnTypeVarchar:   "VARCHAR",
+		ColumnTypeChar:      "CHAR",
+		ColumnTypeText:      "LVARCHAR",
+		ColumnTypeTinyInt:   "SMALLINT",
+		ColumnTypeSmallInt:  "SMALLINT",
+		ColumnTypeInt:       "INTEGER",
+		ColumnTypeBigInt:    "BIGINT",
+		ColumnTypeBoolean:   "BOOLEAN",
+		ColumnTypeReal:      "SMALLFLOAT",
+		ColumnTypeDouble:    "FLOAT",
+		ColumnTypeDecimal:   "DECIMAL",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "DATETIME HOUR TO SECOND",
+		ColumnTypeDateTime:  "DATETIME YEAR TO SECOND",
+		ColumnTypeTimestamp: "DATETIME YEAR TO FRACTION(3)",
+		ColumnTypeBlob:      "BLOB",
+		ColumnTypeJson:      "JSON",
+		ColumnTypeUuid:      "CHAR(36)",
+		ColumnTypeBit:       "BOOLEAN",
+		ColumnTypeBinary:    "BYTE",
+		ColumnTypeVarbinary: "BYTE",
+		ColumnTypeMoney:     "MONEY",
+		ColumnTypeXml:       "LVARCHAR",
+	},
+	MariaDB: {
+		ColumnTypeVarchar:   "VARCHAR",
+		ColumnTypeChar:      "CHAR",
+		ColumnTypeText:      "TEXT",
+		ColumnTypeTinyInt:   "TINYINT",
+		ColumnTypeSmallInt:  "SMALLINT",
+		ColumnTypeInt:       "INT",
+		ColumnTypeBigInt:    "BIGINT",
+		ColumnTypeBoolean:   "BOOLEAN",
+		ColumnTypeReal:      "FLOAT",
+		ColumnTypeDouble:    "DOUBLE",
+		ColumnTypeDecimal:   "DECIMAL",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIME",
+		ColumnTypeDateTime:  "DATETIME",
+		ColumnTypeTimestamp: "TIMESTAMP",
+		ColumnTypeBlob:      "BLOB",
+		ColumnTypeJson:      "JSON",
+		ColumnTypeUuid:      "UUID",
+		ColumnTypeBit:       "BIT",
+		ColumnTypeBinary:    "BINARY",
+		ColumnTypeVarbinary: "VARBINARY",
+		ColumnTypeMoney:     "DECIMAL",
+		ColumnTypeXml:       "TEXT",
+	},
+	CockroachDB: {
+		ColumnTypeVarchar:   "STRING",
+		ColumnTypeChar:      "CHAR",
+		ColumnTypeText:      "STRING",
+		ColumnTypeTinyInt:   "INT2",
+		ColumnTypeSmallInt:  "INT2",
+		ColumnTypeInt:       "INT4",
+		ColumnTypeBigInt:    "INT8",
+		ColumnTypeBoolean:   "BOOL",
+		ColumnTypeReal:      "FLOAT4",
+		ColumnTypeDouble:    "FLOAT8",
+		ColumnTypeDecimal:   "DECIMAL",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIME",
+		ColumnTypeDateTime:  "TIMESTAMP",
+		ColumnTypeTimestamp: "TIMESTAMPTZ",
+		ColumnTypeBlob:      "BYTES",
+		ColumnTypeJson:      "JSONB",
+		ColumnTypeUuid:      "UUID",
+		ColumnTypeBit:       "BIT",
+		ColumnTypeBinary:    "BYTES",
+		ColumnTypeVarbinary: "BYTES",
+		ColumnTypeMoney:     "DECIMAL",
+		ColumnTypeXml:       "STRING",
+	},
+	DuckDB: {
+		ColumnTypeVarchar:   "VARCHAR",
+		ColumnTypeChar:      "VARCHAR",
+		ColumnTypeText:      "VARCHAR",
+		ColumnTypeTinyInt:   "TINYINT",
+		ColumnTypeSmallInt:  "SMALLINT",
+		ColumnTypeInt:       "INTEGER",
+		ColumnTypeBigInt:    "BIGINT",
+		ColumnTypeBoolean:   "BOOLEAN",
+		ColumnTypeReal:      "REAL",
+		ColumnTypeDouble:    "DOUBLE",
+		ColumnTypeDecimal:   "DECIMAL",
+		ColumnTypeDate:      "DATE",
+		ColumnTypeTime:      "TIME",
+		ColumnTypeDateTime:  "TIMESTAMP",
+		ColumnTypeTimestamp: "TIMESTAMPTZ",
+		ColumnTypeBlob:      "BLOB",
+		ColumnTypeJson:      "JSON",
+		ColumnTypeUuid:      "UUID",
+		ColumnTypeBit:       "BIT",
+		ColumnTypeBinary:    "BLOB",
+		ColumnTypeVarbinary: "BLOB",
+		ColumnTypeMoney:     "DECIMAL",
+		ColumnTypeXml:       "VARCHAR",
+	},
 }
 
 // getBaseSQLType retrieves the base SQL type for the abstract type.
@@ -134,8 +435,9 @@ func GetSQLType(flavor Flavor, col interface{}) string {
 		GetScale() *int
 	}); ok {
 		colType = c.GetType()
-		if at, ok := c.GetAbstractType().(ColumnType); ok {
-			abstractType = at
+		// Abstract types are declared in the types package; accept any integer-kinded value
+		if at := reflect.ValueOf(c.GetAbstractType()); at.Kind() == reflect.Int {
+			abstractType = ColumnType(at.Int())
 		}
 		length = c.GetLength()
 		precision = c.GetPrecision()
@@ -145,6 +447,11 @@ func GetSQLType(flavor Flavor, col interface{}) string {
 	if colType != "" {
 		return colType
 	}
+	return SQLType(flavor, abstractType, length, precision, scale)
+}
+
+// SQLType returns the full SQL type string of an abstract type with its length, precision and scale.
+func SQLType(flavor Flavor, abstractType ColumnType, length, precision, scale *int) string {
 	base := getBaseSQLType(flavor, abstractType)
 	switch abstractType {
 	case ColumnTypeVarchar, ColumnTypeChar, ColumnTypeBinary, ColumnTypeVarbinary, ColumnTypeBit:
@@ -167,8 +474,10 @@ func GetSQLType(flavor Flavor, col interface{}) string {
 			switch abstractType {
 			case ColumnTypeBit:
 				switch flavor {
-				case MySQL, PostgreSQL:
+				case MySQL, PostgreSQL, MariaDB, CockroachDB:
 					appendStr = fmt.Sprintf("(%d)", colLength)
+				case DuckDB:
+					// Bit strings are variable-length
 				case Presto:
 					base = "VARBIT"
 					appendStr = fmt.Sprintf("(%d)", colLength)
@@ -183,19 +492,19 @@ func GetSQLType(flavor Flavor, col interface{}) string {
 						panic(fmt.Sprintf("multi-bit fields not supported for flavor %s", flavor))
 					}
 				}
-			case ColumnTypeBinary, ColumnTypeChar:
+			case ColumnTypeChar, ColumnTypeVarchar:
 				switch flavor {
-				case MySQL, SQLServer, Oracle, PostgreSQL, Presto, Informix:
+				case MySQL, SQLServer, Oracle, PostgreSQL, Presto, Informix, MariaDB, CockroachDB:
 					appendStr = fmt.Sprintf("(%d)", colLength)
 				default:
-					// Ignore length for others like BYTEA, BLOB
+					// Ignore for others
 				}
-			case ColumnTypeVarbinary, ColumnTypeVarchar:
+			case ColumnTypeBinary, ColumnTypeVarbinary:
 				switch flavor {
-				case MySQL, SQLServer, Oracle, PostgreSQL, Presto, Informix:
+				case MySQL, SQLServer, Oracle, MariaDB:
 					appendStr = fmt.Sprintf("(%d)", colLength)
 				default:
-					// Ignore for others
+					// Ignore length for others like BYTEA, BLOB
 				}
 			}
 		}
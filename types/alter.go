@@ -0,0 +1,187 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// DropOption modifies a DROP statement.
+type DropOption int
+
+const (
+	IfExists DropOption = iota + 1 // Do nothing if the object does not exist
+	Cascade                        // Also drop objects that depend on it, such as foreign keys
+)
+
+// dropClauses returns the IF EXISTS prefix and CASCADE suffix of a DROP statement. Options the
+// flavor does not support issue a warning and are ignored.
+func dropClauses(flavor flavors.Flavor, object string, isTable bool, opts []DropOption) (ifExists, cascade string) {
+	for _, opt := range opts {
+		switch opt {
+		case IfExists:
+			if flavor.Base() == flavors.Oracle {
+				warnf("IF EXISTS on drop of %s ignored: not supported by %s", object, flavor)
+			} else {
+				ifExists = "IF EXISTS "
+			}
+		case Cascade:
+			switch {
+			case flavor.Base() == flavors.PostgreSQL, flavor.Base() == flavors.CockroachDB, flavor.Base() == flavors.DuckDB,
+				flavor.Base() == flavors.Informix && isTable:
+				cascade = " CASCADE"
+			case flavor.Base() == flavors.Oracle && isTable:
+				cascade = " CASCADE CONSTRAINTS"
+			default:
+				warnf("CASCADE on drop of %s ignored: not supported by %s", object, flavor)
+			}
+		}
+	}
+	return ifExists, cascade
+}
+
+// dropStatement builds DROP <kind> [IF EXISTS] name. SQL Server before 2016 has no IF EXISTS and
+// checks OBJECT_ID for an object of the given type instead, e.g. "U" for tables.
+func dropStatement(flavor flavors.Flavor, kind, ifExists, name, objectType string) string {
+	if ifExists != "" && flavor.Base() == flavors.SQLServer && flavor.Before("2016") {
+		return "IF OBJECT_ID(N'" + strings.ReplaceAll(name, "'", "''") + "', N'" + objectType + "') IS NOT NULL DROP " + kind + " " + name
+	}
+	return "DROP " + kind + " " + ifExists + name
+}
+
+// BuildDrop builds the DROP TABLE SQL for the given flavor, e.g. BuildDrop(flavors.PostgreSQL,
+// IfExists, Cascade). The enum types, trigger functions and sequences created by BuildCreate on
+// PostgreSQL, CockroachDB and DuckDB are dropped after it, separated by ";\n".
+func (t *Table) BuildDrop(flavor flavors.Flavor, opts ...DropOption) string {
+	ifExists, cascade := dropClauses(flavor, "table "+t.Name, true, opts)
+	stmts := []string{dropStatement(flavor, "TABLE", ifExists, t.quotedName(flavor), "U") + cascade}
+	for _, col := range t.Columns {
+		if isEnum(col) && (flavor.Base() == flavors.PostgreSQL || flavor.Base() == flavors.CockroachDB) {
+			stmts = append(stmts, "DROP TYPE "+ifExists+t.quotedEnumTypeName(flavor, col)+cascade)
+		}
+		if col.OnUpdateNow && flavor.Base() == flavors.PostgreSQL {
+			stmts = append(stmts, "DROP FUNCTION "+ifExists+quoteQualifiedName(flavor, t.Schema, t.Name+"_"+col.Name+"_on_update")+"()"+cascade)
+		}
+		if col.AutoIncrement && flavor.Base() == flavors.DuckDB {
+			seq := t.autoIncrementSequence(col)
+			stmts = append(stmts, "DROP SEQUENCE "+ifExists+quoteQualifiedName(flavor, seq.Schema, seq.Name)+cascade)
+		}
+	}
+	return strings.Join(stmts, ";\n")
+}
+
+// BuildTruncate builds the statement deleting every row of the table. SQLite has no TRUNCATE
+// and uses an unqualified DELETE instead.
+func (t *Table) BuildTruncate(flavor flavors.Flavor) string {
+	if flavor.Base() == flavors.SQLite {
+		return "DELETE FROM " + t.quotedName(flavor)
+	}
+	return "TRUNCATE TABLE " + t.quotedName(flavor)
+}
+
+// alterTable returns the ALTER TABLE prefix of the table.
+func (t *Table) alterTable(flavor flavors.Flavor) string {
+	return "ALTER TABLE " + t.quotedName(flavor)
+}
+
+// BuildAddColumn builds the ALTER TABLE statement adding a column to the table. On PostgreSQL
+// and CockroachDB, the type of an enum column is created first; a foreign key is added after the
+// column, except on SQLite, which cannot add constraints and references inline. Statements are
+// separated by ";\n".
+func (t *Table) BuildAddColumn(flavor flavors.Flavor, col *Column[any]) string {
+	def := t.columnDefinition(flavor, col)
+	reference := col.References != nil && supportsForeignKeys(flavor)
+	if reference && flavor.Base() == flavors.SQLite {
+		def += " REFERENCES " + flavor.QuoteQualified(col.References.Table) + " (" + flavor.Quote(col.References.Column) + ")"
+	}
+	var sql string
+	switch flavor.Base() {
+	case flavors.SQLServer:
+		sql = t.alterTable(flavor) + " ADD " + def
+	case flavors.Oracle, flavors.Informix:
+		sql = t.alterTable(flavor) + " ADD (" + def + ")"
+	case flavors.CQL:
+		sql = t.alterTable(flavor) + " ADD " + def
+	default:
+		sql = t.alterTable(flavor) + " ADD COLUMN " + def
+	}
+	if (flavor.Base() == flavors.PostgreSQL || flavor.Base() == flavors.CockroachDB) && isEnum(col) {
+		sql = "CREATE TYPE " + t.quotedEnumTypeName(flavor, col) + " AS ENUM (" + strings.Join(enumLiterals(col), ", ") + ");\n" + sql
+	}
+	if reference && flavor.Base() != flavors.SQLite {
+		sql += ";\n" + t.buildAddForeignKey(flavor, col)
+	}
+	return sql
+}
+
+// BuildDropColumn builds the ALTER TABLE statement dropping the named column.
+func (t *Table) BuildDropColumn(flavor flavors.Flavor, name string) string {
+	switch flavor.Base() {
+	case flavors.Informix:
+		return t.alterTable(flavor) + " DROP (" + flavor.Quote(name) + ")"
+	case flavors.CQL:
+		return t.alterTable(flavor) + " DROP " + flavor.Quote(name)
+	default:
+		return t.alterTable(flavor) + " DROP COLUMN " + flavor.Quote(name)
+	}
+}
+
+// BuildAlterColumnType builds the ALTER TABLE statement changing a column to the type of col.
+// MySQL, MariaDB, Oracle and Informix redefine the whole column, including its default; the other
+// flavors set the bare type, as auto-increment types such as SERIAL are only valid on creation.
+// SQLite cannot change column types in place, so the table is rebuilt, see TableDiff.BuildRebuild.
+// CQL, which cannot change column types either, warns and returns an empty statement.
+func (t *Table) BuildAlterColumnType(flavor flavors.Flavor, col *Column[any]) string {
+	column := flavor.Quote(col.Name)
+	bare := *col
+	bare.AutoIncrement = false
+	sqlType := t.columnType(flavor, &bare)
+	switch flavor.Base() {
+	case flavors.PostgreSQL, flavors.CockroachDB, flavors.DuckDB:
+		return t.alterTable(flavor) + " ALTER COLUMN " + column + " TYPE " + sqlType + " USING " + column + "::" + sqlType
+	case flavors.MySQL, flavors.MariaDB:
+		return t.alterTable(flavor) + " MODIFY COLUMN " + t.columnDefinition(flavor, col)
+	case flavors.Oracle, flavors.Informix:
+		return t.alterTable(flavor) + " MODIFY (" + t.columnDefinition(flavor, col) + ")"
+	case flavors.SQLServer:
+		return t.alterTable(flavor) + " ALTER COLUMN " + column + " " + sqlType
+	case flavors.ClickHouse:
+		return t.alterTable(flavor) + " MODIFY COLUMN " + column + " " + sqlType
+	case flavors.Presto:
+		return t.alterTable(flavor) + " ALTER COLUMN " + column + " SET DATA TYPE " + sqlType
+	case flavors.CQL:
+		warnf("type change of column %s.%s ignored: not supported by %s", t.Name, col.Name, flavor)
+		return ""
+	case flavors.SQLite:
+		to := *t
+		to.Columns = make([]*Column[any], len(t.Columns))
+		for i, current := range t.Columns {
+			if current.Name == col.Name {
+				current = col
+			}
+			to.Columns[i] = current
+		}
+		return TableDiff{From: t, To: &to}.BuildRebuild(flavor)
+	default:
+		panic(fmt.Sprintf("changing column types not supported for flavor: %s", flavor))
+	}
+}
+
+// BuildRenameColumn builds the statement renaming a column of the table.
+func (t *Table) BuildRenameColumn(flavor flavors.Flavor, oldName, newName string) string {
+	switch flavor.Base() {
+	case flavors.SQLServer:
+		schema := t.Schema
+		if schema == "" {
+			schema = "dbo"
+		}
+		return "EXEC sp_rename " + quoteLiteral(schema+"."+t.Name+"."+oldName) + ", " + quoteLiteral(newName) + ", 'COLUMN'"
+	case flavors.Informix:
+		return "RENAME COLUMN " + t.quotedName(flavor) + "." + flavor.Quote(oldName) + " TO " + flavor.Quote(newName)
+	case flavors.CQL:
+		return t.alterTable(flavor) + " RENAME " + flavor.Quote(oldName) + " TO " + flavor.Quote(newName)
+	default:
+		return t.alterTable(flavor) + " RENAME COLUMN " + flavor.Quote(oldName) + " TO " + flavor.Quote(newName)
+	}
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// ClickHouseOptions holds the MergeTree clauses of a ClickHouse table besides its engine,
+// sorting key and partition key, which are set on the Table.
+type ClickHouseOptions struct {
+	PrimaryKey []string // Defaults to the sorting key
+	SampleBy   string
+	TTL        string // e.g. "ts + INTERVAL 30 DAY"
+	Settings   map[string]string
+}
+
+// MergeTree returns the MergeTree engine, the default of ClickHouse tables.
+func MergeTree() string { return "MergeTree()" }
+
+// ReplacingMergeTree returns the ReplacingMergeTree engine, keeping the row with the highest
+// version column among rows with the same sorting key; an empty version keeps the last inserted.
+func ReplacingMergeTree(version string) string { return "ReplacingMergeTree(" + version + ")" }
+
+// SummingMergeTree returns the SummingMergeTree engine, summing columns, or every numeric
+// column when none are given, among rows with the same sorting key.
+func SummingMergeTree(columns ...string) string {
+	if len(columns) == 0 {
+		return "SummingMergeTree()"
+	}
+	return "SummingMergeTree((" + strings.Join(columns, ", ") + "))"
+}
+
+// AggregatingMergeTree returns the AggregatingMergeTree engine.
+func AggregatingMergeTree() string { return "AggregatingMergeTree()" }
+
+// CollapsingMergeTree returns the CollapsingMergeTree engine collapsing rows by the sign column.
+func CollapsingMergeTree(sign string) string { return "CollapsingMergeTree(" + sign + ")" }
+
+// ReplicatedMergeTree returns the ReplicatedMergeTree engine replicated through the ZooKeeper path.
+func ReplicatedMergeTree(path, replica string) string {
+	return "ReplicatedMergeTree(" + quoteLiteral(path) + ", " + quoteLiteral(replica) + ")"
+}
+
+// WithLowCardinality wraps the ClickHouse type of the column in LowCardinality; other flavors ignore it.
+func WithLowCardinality[T any]() ColumnOption[T] {
+	return func(column *Column[T]) { column.LowCardinality = true }
+}
+
+// WithNullable wraps the ClickHouse type of the column in Nullable, as ClickHouse columns are
+// not nullable by default; other flavors ignore it.
+func WithNullable[T any]() ColumnOption[T] {
+	return func(column *Column[T]) { column.Nullable = true }
+}
+
+// clickHouseType wraps sqlType in the Nullable and LowCardinality modifiers of the column.
+func clickHouseType(col *Column[any], sqlType string) string {
+	if col.Nullable {
+		sqlType = ColumnTypeClickHouseNullable.String() + "(" + sqlType + ")"
+	}
+	if col.LowCardinality {
+		sqlType = ColumnTypeClickHouseLowCardinality.String() + "(" + sqlType + ")"
+	}
+	return sqlType
+}
+
+// clickHouseOptions returns the ENGINE and MergeTree clauses of a ClickHouse table.
+func (t *Table) clickHouseOptions() []string {
+	engine := t.Engine
+	if engine == "" {
+		engine = MergeTree()
+	}
+	opts := []string{"ENGINE = " + engine}
+	if t.PartitionBy != "" {
+		opts = append(opts, "PARTITION BY "+t.PartitionBy)
+	}
+	if len(t.OrderBy) > 0 {
+		opts = append(opts, "ORDER BY ("+strings.Join(t.OrderBy, ", ")+")")
+	} else if strings.Contains(engine, "MergeTree") {
+		// MergeTree engines require a sorting key
+		opts = append(opts, "ORDER BY tuple()")
+	}
+	ch := t.ClickHouse
+	if ch == nil {
+		return opts
+	}
+	if len(ch.PrimaryKey) > 0 {
+		opts = append(opts, "PRIMARY KEY ("+strings.Join(ch.PrimaryKey, ", ")+")")
+	}
+	if ch.SampleBy != "" {
+		opts = append(opts, "SAMPLE BY "+ch.SampleBy)
+	}
+	if ch.TTL != "" {
+		opts = append(opts, "TTL "+ch.TTL)
+	}
+	if len(ch.Settings) > 0 {
+		names := make([]string, 0, len(ch.Settings))
+		for name := range ch.Settings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		settings := make([]string, len(names))
+		for i, name := range names {
+			settings[i] = name + " = " + ch.Settings[name]
+		}
+		opts = append(opts, "SETTINGS "+strings.Join(settings, ", "))
+	}
+	return opts
+}
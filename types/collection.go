@@ -0,0 +1,133 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// Array creates an array column of elements of type elem, e.g. Array[int32]("scores", ColumnTypeInt).
+// It is a native array on PostgreSQL, CockroachDB, ClickHouse and Presto, a list on CQL, Informix and
+// DuckDB, and JSON elsewhere.
+func Array[T any](name string, elem ColumnType, args ...ColumnOption[[]T]) *Column[any] {
+	column := createType(name, ColumnTypePostgresArray, args...)
+	column.ElementType = elem
+	return column
+}
+
+// List creates a list column of elements of type elem, e.g. List[string]("tags", ColumnTypeText).
+// Outside CQL it is rendered like Array.
+func List[T any](name string, elem ColumnType, args ...ColumnOption[[]T]) *Column[any] {
+	column := createType(name, ColumnTypeCQLList, args...)
+	column.ElementType = elem
+	return column
+}
+
+// Set creates a set column of unique elements of type elem. Uniqueness is enforced by CQL and
+// Informix only; elsewhere it is rendered like Array.
+func Set[T comparable](name string, elem ColumnType, args ...ColumnOption[[]T]) *Column[any] {
+	column := createType(name, ColumnTypeCQLSet, args...)
+	column.ElementType = elem
+	return column
+}
+
+// SetOf creates a column holding any subset of values: a native SET on MySQL and MariaDB, a text
+// array restricted to values by a CHECK constraint on PostgreSQL, CockroachDB and DuckDB and a set
+// of text elsewhere.
+func SetOf(name string, values []string, args ...ColumnOption[[]string]) *Column[any] {
+	column := createType(name, ColumnTypeMySQLSet, args...)
+	column.ElementType = ColumnTypeText
+	column.EnumValues = values
+	return column
+}
+
+// Map creates a map column from keys of type key to values of type value. It is a native map on
+// CQL, ClickHouse, Presto and DuckDB, and JSON elsewhere.
+func Map[K comparable, V any](name string, key, value ColumnType, args ...ColumnOption[map[K]V]) *Column[any] {
+	column := createType(name, ColumnTypeCQLMap, args...)
+	column.KeyType = key
+	column.ElementType = value
+	return column
+}
+
+// Counter creates a CQL counter column, a BIGINT on other flavors.
+func Counter(name string, args ...ColumnOption[int64]) *Column[any] {
+	return createType(name, ColumnTypeCQLCounter, args...)
+}
+
+// isCollection reports whether the column was declared with Array, List, Set, SetOf or Map.
+func isCollection(col *Column[any]) bool {
+	switch col.AbstractType {
+	case ColumnTypePostgresArray, ColumnTypeCQLList, ColumnTypeCQLSet, ColumnTypeCQLMap:
+		return true
+	case ColumnTypeMySQLSet:
+		return len(col.EnumValues) > 0
+	default:
+		return false
+	}
+}
+
+// elementSQLType returns the SQL type of collection elements of abstract type ct.
+func elementSQLType(flavor flavors.Flavor, ct ColumnType) string {
+	return columnSQLType(flavor, &Column[any]{AbstractType: ct})
+}
+
+// collectionType returns the SQL type of a collection column, with a CHECK constraint restricting
+// SetOf columns to their values on PostgreSQL, CockroachDB and DuckDB.
+func collectionType(flavor flavors.Flavor, col *Column[any]) string {
+	elem := elementSQLType(flavor, col.ElementType)
+	if col.AbstractType == ColumnTypeCQLMap {
+		key := elementSQLType(flavor, col.KeyType)
+		switch flavor.Base() {
+		case flavors.CQL:
+			return "map<" + key + ", " + elem + ">"
+		case flavors.ClickHouse:
+			return ColumnTypeClickHouseMap.String() + "(" + key + ", " + elem + ")"
+		case flavors.Presto, flavors.DuckDB:
+			return "MAP(" + key + ", " + elem + ")"
+		default:
+			return elementSQLType(flavor, ColumnTypeJson)
+		}
+	}
+	unique := col.AbstractType == ColumnTypeCQLSet || col.AbstractType == ColumnTypeMySQLSet
+	switch flavor.Base() {
+	case flavors.MySQL, flavors.MariaDB:
+		if col.AbstractType == ColumnTypeMySQLSet {
+			return "SET(" + strings.Join(enumLiterals(col), ", ") + ")"
+		}
+	case flavors.PostgreSQL, flavors.CockroachDB:
+		if col.AbstractType == ColumnTypeMySQLSet {
+			return elem + "[] CHECK (" + flavor.Quote(col.Name) + " <@ ARRAY[" + strings.Join(enumLiterals(col), ", ") + "]::" + elem + "[])"
+		}
+		return elem + "[]"
+	case flavors.CQL:
+		if unique {
+			return "set<" + elem + ">"
+		}
+		return "list<" + elem + ">"
+	case flavors.ClickHouse:
+		return ColumnTypeClickHouseArray.String() + "(" + elem + ")"
+	case flavors.Presto:
+		return "ARRAY(" + elem + ")"
+	case flavors.Informix:
+		if unique {
+			return "SET(" + elem + " NOT NULL)"
+		}
+		return "LIST(" + elem + " NOT NULL)"
+	case flavors.DuckDB:
+		// T[] is DuckDB's LIST type
+		if col.AbstractType == ColumnTypeMySQLSet {
+			return elem + "[] CHECK (list_has_all([" + strings.Join(enumLiterals(col), ", ") + "], " + flavor.Quote(col.Name) + "))"
+		}
+		return elem + "[]"
+	}
+	return elementSQLType(flavor, ColumnTypeJson)
+}
+
+// counterType returns the SQL type of a Counter column.
+func counterType(flavor flavors.Flavor) string {
+	if flavor.Base() == flavors.CQL {
+		return "counter"
+	}
+	return elementSQLType(flavor, ColumnTypeBigInt)
+}
@@ -0,0 +1,223 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// PGArray is a slice model field stored as a PostgreSQL array. Like pq.Array, it scans array
+// literals such as {1,2,3} and is written as one.
+type PGArray[T any] []T
+
+// JSONArray is a slice model field stored as a JSON array, on flavors without array columns.
+type JSONArray[T any] []T
+
+// JSONMap is a map model field stored as a JSON object, on flavors without map columns.
+type JSONMap[K comparable, V any] map[K]V
+
+// MySQLSet is a MySQL SET model field, scanned from and written as comma-separated values.
+type MySQLSet []string
+
+// Scan implements sql.Scanner.
+func (a *PGArray[T]) Scan(src any) error { return scanSlice(src, (*[]T)(a)) }
+
+// Value implements driver.Valuer.
+func (a PGArray[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	elems := make([]string, len(a))
+	for i, v := range a {
+		elems[i] = formatPGElement(v)
+	}
+	return "{" + strings.Join(elems, ",") + "}", nil
+}
+
+// Scan implements sql.Scanner.
+func (a *JSONArray[T]) Scan(src any) error { return scanSlice(src, (*[]T)(a)) }
+
+// Value implements driver.Valuer.
+func (a JSONArray[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal([]T(a))
+	return string(b), err
+}
+
+// Scan implements sql.Scanner.
+func (m *JSONMap[K, V]) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case map[K]V:
+		*m = v
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*map[K]V)(m))
+	case string:
+		return json.Unmarshal([]byte(v), (*map[K]V)(m))
+	default:
+		return fmt.Errorf("grizzle-kit: cannot scan %T into a map", src)
+	}
+}
+
+// Value implements driver.Valuer.
+func (m JSONMap[K, V]) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(map[K]V(m))
+	return string(b), err
+}
+
+// Scan implements sql.Scanner.
+func (s *MySQLSet) Scan(src any) error { return scanSlice(src, (*[]string)(s)) }
+
+// Value implements driver.Valuer.
+func (s MySQLSet) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return strings.Join(s, ","), nil
+}
+
+// scanSlice scans a native slice, a JSON array, a PostgreSQL array literal or comma-separated
+// values into dst.
+func scanSlice[T any](src any, dst *[]T) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		*dst = nil
+		return nil
+	case []T:
+		*dst = v
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("grizzle-kit: cannot scan %T into a slice", src)
+	}
+	text = strings.TrimSpace(text)
+	var elems []string
+	var nulls []bool
+	switch {
+	case strings.HasPrefix(text, "["):
+		return json.Unmarshal([]byte(text), dst)
+	case strings.HasPrefix(text, "{"):
+		var err error
+		if elems, nulls, err = parsePGArray(text); err != nil {
+			return err
+		}
+	case text != "":
+		elems = strings.Split(text, ",")
+		nulls = make([]bool, len(elems))
+	}
+	values := make([]T, len(elems))
+	for i, elem := range elems {
+		if nulls[i] {
+			continue
+		}
+		if err := parseElement(elem, &values[i]); err != nil {
+			return err
+		}
+	}
+	*dst = values
+	return nil
+}
+
+// parsePGArray splits a one-dimensional PostgreSQL array literal into its elements.
+func parsePGArray(text string) (elems []string, nulls []bool, err error) {
+	if !strings.HasSuffix(text, "}") {
+		return nil, nil, fmt.Errorf("grizzle-kit: malformed array literal %q", text)
+	}
+	body := text[1 : len(text)-1]
+	if body == "" {
+		return []string{}, []bool{}, nil
+	}
+	var elem strings.Builder
+	quoted, inQuotes := false, false
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case inQuotes && c == '\\' && i+1 < len(body):
+			i++
+			elem.WriteByte(body[i])
+		case c == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case c == '{' && !inQuotes:
+			return nil, nil, fmt.Errorf("grizzle-kit: multidimensional arrays are not supported: %q", text)
+		case c == ',' && !inQuotes:
+			elems = append(elems, elem.String())
+			nulls = append(nulls, !quoted && elem.String() == "NULL")
+			elem.Reset()
+			quoted = false
+		default:
+			elem.WriteByte(c)
+		}
+	}
+	elems = append(elems, elem.String())
+	nulls = append(nulls, !quoted && elem.String() == "NULL")
+	return elems, nulls, nil
+}
+
+// parseElement converts the text of an array element into dst.
+func parseElement[T any](text string, dst *T) error {
+	switch p := any(dst).(type) {
+	case *string:
+		*p = text
+	case *bool:
+		*p = text == "t" || text == "true"
+	case *time.Time:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07", "2006-01-02 15:04:05.999999999", "2006-01-02"} {
+			if t, err := time.Parse(layout, text); err == nil {
+				*p = t
+				return nil
+			}
+		}
+		return fmt.Errorf("grizzle-kit: cannot parse %q as a time", text)
+	case encoding.TextUnmarshaler:
+		return p.UnmarshalText([]byte(text))
+	default:
+		if rv := reflect.ValueOf(dst).Elem(); rv.Kind() == reflect.String {
+			rv.SetString(text)
+			return nil
+		}
+		return json.Unmarshal([]byte(text), dst)
+	}
+	return nil
+}
+
+// formatPGElement formats a value as a PostgreSQL array element.
+func formatPGElement(v any) string {
+	switch e := v.(type) {
+	case string:
+		return quotePGElement(e)
+	case time.Time:
+		return quotePGElement(e.Format(time.RFC3339Nano))
+	case []byte:
+		return quotePGElement(`\x` + hex.EncodeToString(e))
+	case encoding.TextMarshaler:
+		b, _ := e.MarshalText()
+		return quotePGElement(string(b))
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+			return quotePGElement(rv.String())
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+// quotePGElement quotes an array element, escaping quotes and backslashes.
+func quotePGElement(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"reflect"
 	"time"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
 )
 
 // Numeric represents numeric types that can be used with auto-increment
@@ -14,22 +16,82 @@ type Numeric interface {
 
 // Column represents a table column with generic type T.
 type Column[T any] struct {
-	ParentAlias   string
-	Name          string
-	Type          string     // Manual SQL type override
-	AbstractType  ColumnType // Abstract type for equivalent mapping
-	Default       T
-	HasDefault    bool
-	AutoIncrement bool
-	Length        *int // For string types like varchar, char
-	Precision     *int // For decimal
-	Scale         *int // For decimal
+	ParentAlias    string
+	Name           string
+	Type           string     // Manual SQL type override
+	AbstractType   ColumnType // Abstract type for equivalent mapping
+	Default        T
+	DefaultExpr    *Expr // SQL expression default, takes precedence over Default
+	HasDefault     bool
+	AutoIncrement  bool
+	Length         *int // For string types like varchar, char
+	Precision      *int // For decimal
+	Scale          *int // For decimal
+	References     *ForeignKey
+	GoType         string   // Go type used in generated code, e.g. "github.com/shopspring/decimal.Decimal"
+	EnumValues     []string // Allowed values of enum columns
+	Check          string   // CHECK constraint expression
+	Generated      *Generated
+	OnUpdateNow    bool // Set to the current timestamp whenever the row is updated
+	Comment        string
+	LowCardinality bool       // ClickHouse LowCardinality(T)
+	Nullable       bool       // ClickHouse Nullable(T)
+	ElementType    ColumnType // Element type of List and Set columns, value type of Map columns
+	KeyType        ColumnType // Key type of Map columns
+	Domain         string     // PostgreSQL domain of the column, see Domain.Column
+}
+
+// GeneratedKind selects whether a generated column is computed on read or stored on write.
+type GeneratedKind int
+
+const (
+	Virtual GeneratedKind = iota
+	Stored
+)
+
+func (k GeneratedKind) String() string {
+	if k == Stored {
+		return "STORED"
+	}
+	return "VIRTUAL"
+}
+
+// Generated describes a column computed from an SQL expression.
+type Generated struct {
+	Expr string
+	Kind GeneratedKind
+}
+
+// Assignment is a column value written by generated update helpers.
+type Assignment struct {
+	Column   string
+	Value    any
+	ReadOnly bool // Set for generated columns, which cannot be written
+}
+
+// ForeignKey describes a reference from a column to a column of another table.
+type ForeignKey struct {
+	Table  string
+	Column string
 }
 
 func (c *Column[T]) String() string {
 	return c.ParentAlias + "." + c.Name
 }
 
+// Quoted returns the column reference with the parent alias and name quoted for the given flavor.
+func (c *Column[T]) Quoted(flavor flavors.Flavor) string {
+	if c.ParentAlias == "" {
+		return flavor.Quote(c.Name)
+	}
+	return flavor.Quote(c.ParentAlias) + "." + flavor.Quote(c.Name)
+}
+
+// Set returns an assignment of value to the column for generated update helpers.
+func (c *Column[T]) Set(value T) Assignment {
+	return Assignment{Column: c.Name, Value: value, ReadOnly: c.Generated != nil}
+}
+
 // Getter methods for mapping package compatibility
 func (c *Column[T]) GetType() string              { return c.Type }
 func (c *Column[T]) GetAbstractType() interface{} { return c.AbstractType }
@@ -65,6 +127,21 @@ func WithDefault[T any](value T) ColumnOption[T] {
 	}
 }
 
+// WithDefaultExpr sets an SQL expression as the default value, e.g. WithDefaultExpr[time.Time](Now()).
+func WithDefaultExpr[T any](expr Expr) ColumnOption[T] {
+	return func(column *Column[T]) {
+		column.DefaultExpr = &expr
+		column.HasDefault = true
+	}
+}
+
+// WithOnUpdateNow sets the column to the current timestamp whenever its row is updated. MySQL uses
+// ON UPDATE CURRENT_TIMESTAMP, PostgreSQL and SQLite a trigger created by BuildCreate; elsewhere the
+// generated Update helper sets it.
+func WithOnUpdateNow[T any]() ColumnOption[T] {
+	return func(column *Column[T]) { column.OnUpdateNow = true }
+}
+
 // WithAutoIncrement enables auto-increment for numeric columns.
 func WithAutoIncrement[T Numeric](active bool) ColumnOption[T] {
 	return func(column *Column[T]) {
@@ -85,6 +162,34 @@ func WithPrecision[T any](precision, scale int) ColumnOption[T] {
 	}
 }
 
+// WithGoType sets the Go type used for the column in generated code.
+// Qualified types are written as import path and type name, e.g. "github.com/google/uuid.UUID".
+func WithGoType[T any](goType string) ColumnOption[T] {
+	return func(column *Column[T]) { column.GoType = goType }
+}
+
+// WithCheck adds a CHECK constraint on the column, named <table>_<column>_check.
+func WithCheck[T any](expr string) ColumnOption[T] {
+	return func(column *Column[T]) { column.Check = expr }
+}
+
+// WithGenerated makes the column computed from expr, either Stored or Virtual.
+func WithGenerated[T any](expr string, kind GeneratedKind) ColumnOption[T] {
+	return func(column *Column[T]) { column.Generated = &Generated{Expr: expr, Kind: kind} }
+}
+
+// WithComment sets the comment of the column, also used as the doc comment of generated fields.
+func WithComment[T any](comment string) ColumnOption[T] {
+	return func(column *Column[T]) { column.Comment = comment }
+}
+
+// WithReferences declares a foreign key from the column to column of table, which may be schema-qualified.
+func WithReferences[T any](table, column string) ColumnOption[T] {
+	return func(c *Column[T]) {
+		c.References = &ForeignKey{Table: table, Column: column}
+	}
+}
+
 // WithAlias creates a new column with the specified alias
 func (c *Column[T]) WithAlias(alias string) *Column[T] {
 	newCol := *c
@@ -175,6 +280,35 @@ func Xml(name string, args ...ColumnOption[string]) *Column[any] {
 	return createType(name, ColumnTypeXml, args...)
 }
 
+// Enum creates a column restricted to values, e.g. Enum("status", "active", "banned"). It maps to a
+// native ENUM on MySQL, a CREATE TYPE ... AS ENUM on PostgreSQL, Enum8/Enum16 on ClickHouse and a
+// CHECK constraint elsewhere. Use EnumWith to pass options.
+func Enum(name string, values ...string) *Column[any] {
+	return EnumWith(name, values)
+}
+
+// EnumWith creates an enum column with options, e.g.
+// EnumWith("status", []string{"active", "banned"}, WithDefault("active")).
+func EnumWith(name string, values []string, args ...ColumnOption[string]) *Column[any] {
+	column := createType(name, ColumnTypeMySQLEnum, args...)
+	column.EnumValues = values
+	return column
+}
+
+// Timestamps returns created_at and updated_at columns defaulting to the current timestamp,
+// with updated_at refreshed on every update. Append them to a table's columns:
+//
+//	Columns: append([]*types.Column[any]{...}, types.Timestamps()...)
+func Timestamps() []*Column[any] {
+	return []*Column[any]{
+		Timestamp("created_at", WithDefaultExpr[time.Time](Now())),
+		Timestamp("updated_at", WithDefaultExpr[time.Time](Now()), WithOnUpdateNow[time.Time]()),
+	}
+}
+
+// Ptr returns a pointer to v, for optional column settings such as Length.
+func Ptr[T any](v T) *T { return &v }
+
 type Transformer = func(any) any
 
 // Convert copies fields from src struct to dst struct, applying optional transformers.
@@ -188,6 +322,10 @@ func Convert[S any, D any](src S, changes map[string]Transformer) D {
 	}
 
 	dstType := reflect.TypeOf((*D)(nil)).Elem()
+	dstIsPtr := dstType.Kind() == reflect.Ptr
+	if dstIsPtr {
+		dstType = dstType.Elem()
+	}
 	dstV := reflect.New(dstType).Elem()
 
 	for i := 0; i < dstV.NumField(); i++ {
@@ -207,5 +345,8 @@ func Convert[S any, D any](src S, changes map[string]Transformer) D {
 			dstField.Set(srcField)
 		}
 	}
+	if dstIsPtr {
+		return dstV.Addr().Interface().(D)
+	}
 	return dstV.Interface().(D)
 }
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
 // ColumnType represents all column types across supported databases.
 // Types are organized by database category with reserved number ranges:
 // 0-27: Shared types (common across databases)
@@ -197,160 +203,284 @@ const (
 	ColumnTypeInformixRow
 )
 
+const (
+	// ===== MARIADB TYPES (9000-9003) =====
+	ColumnTypeMariaDBInet4 ColumnType = 9000 + iota
+	ColumnTypeMariaDBInet6
+	ColumnTypeMariaDBUuid
+	ColumnTypeMariaDBVector
+)
+
+const (
+	// ===== COCKROACHDB TYPES (10000-10012) =====
+	ColumnTypeCockroachDBString ColumnType = 10000 + iota
+	ColumnTypeCockroachDBBytes
+	ColumnTypeCockroachDBInt2
+	ColumnTypeCockroachDBInt4
+	ColumnTypeCockroachDBInt8
+	ColumnTypeCockroachDBFloat4
+	ColumnTypeCockroachDBFloat8
+	ColumnTypeCockroachDBInet
+	ColumnTypeCockroachDBInterval
+	ColumnTypeCockroachDBGeography
+	ColumnTypeCockroachDBGeometry
+	ColumnTypeCockroachDBBox2D
+	ColumnTypeCockroachDBOid
+)
+
+const (
+	// ===== DUCKDB TYPES (11000-11013) =====
+	ColumnTypeDuckDBHugeInt ColumnType = 11000 + iota
+	ColumnTypeDuckDBUHugeInt
+	ColumnTypeDuckDBUTinyInt
+	ColumnTypeDuckDBUSmallInt
+	ColumnTypeDuckDBUInteger
+	ColumnTypeDuckDBUBigInt
+	ColumnTypeDuckDBList
+	ColumnTypeDuckDBStruct
+	ColumnTypeDuckDBMap
+	ColumnTypeDuckDBUnion
+	ColumnTypeDuckDBInterval
+	ColumnTypeDuckDBBitString
+	ColumnTypeDuckDBTimestampNs
+	ColumnTypeDuckDBVarint
+)
+
+// columnTypeNames holds the SQL name of each column type
+var columnTypeNames = map[ColumnType]string{
+	ColumnTypeVarchar:                           "VARCHAR",
+	ColumnTypeChar:                              "CHAR",
+	ColumnTypeText:                              "TEXT",
+	ColumnTypeTinyInt:                           "TINYINT",
+	ColumnTypeSmallInt:                          "SMALLINT",
+	ColumnTypeInt:                               "INT",
+	ColumnTypeBigInt:                            "BIGINT",
+	ColumnTypeBoolean:                           "BOOLEAN",
+	ColumnTypeReal:                              "REAL",
+	ColumnTypeDouble:                            "DOUBLE",
+	ColumnTypeDecimal:                           "DECIMAL",
+	ColumnTypeDate:                              "DATE",
+	ColumnTypeTime:                              "TIME",
+	ColumnTypeDateTime:                          "DATETIME",
+	ColumnTypeTimestamp:                         "TIMESTAMP",
+	ColumnTypeBlob:                              "BLOB",
+	ColumnTypeJson:                              "JSON",
+	ColumnTypeUuid:                              "UUID",
+	ColumnTypeBit:                               "BIT",
+	ColumnTypeBinary:                            "BINARY",
+	ColumnTypeVarbinary:                         "VARBINARY",
+	ColumnTypeMoney:                             "MONEY",
+	ColumnTypeXml:                               "XML",
+	ColumnTypePostgresJsonb:                     "JSONB",
+	ColumnTypePostgresHstore:                    "HSTORE",
+	ColumnTypePostgresTsVector:                  "TSVECTOR",
+	ColumnTypePostgresMoney:                     "MONEY",
+	ColumnTypePostgresInterval:                  "INTERVAL",
+	ColumnTypePostgresInet:                      "INET",
+	ColumnTypePostgresMacaddr:                   "MACADDR",
+	ColumnTypePostgresMacaddr8:                  "MACADDR8",
+	ColumnTypePostgresBit:                       "BIT",
+	ColumnTypePostgresVarbit:                    "VARBIT",
+	ColumnTypePostgresBox:                       "BOX",
+	ColumnTypePostgresCircle:                    "CIRCLE",
+	ColumnTypePostgresLine:                      "LINE",
+	ColumnTypePostgresLseg:                      "LSEG",
+	ColumnTypePostgresPath:                      "PATH",
+	ColumnTypePostgresPolygon:                   "POLYGON",
+	ColumnTypePostgresTsquery:                   "TSQUERY",
+	ColumnTypePostgresJsonpath:                  "JSONPATH",
+	ColumnTypePostgresXml:                       "XML",
+	ColumnTypePostgresArray:                     "ARRAY",
+	ColumnTypePostgresRange:                     "RANGE",
+	ColumnTypePostgresMultirange:                "MULTIRANGE",
+	ColumnTypePostgresPgLsn:                     "PG_LSN",
+	ColumnTypePostgresPgSnapshot:                "PG_SNAPSHOT",
+	ColumnTypeMySQLSet:                          "SET",
+	ColumnTypeMySQLEnum:                         "ENUM",
+	ColumnTypeMySQLPoint:                        "POINT",
+	ColumnTypeMySQLTinytext:                     "TINYTEXT",
+	ColumnTypeMySQLMediumtext:                   "MEDIUMTEXT",
+	ColumnTypeMySQLLongtext:                     "LONGTEXT",
+	ColumnTypeMySQLTinyblob:                     "TINYBLOB",
+	ColumnTypeMySQLMediumblob:                   "MEDIUMBLOB",
+	ColumnTypeMySQLLongblob:                     "LONGBLOB",
+	ColumnTypeMySQLYear:                         "YEAR",
+	ColumnTypeMySQLGeometry:                     "GEOMETRY",
+	ColumnTypeMySQLLinestring:                   "LINESTRING",
+	ColumnTypeMySQLPolygon:                      "POLYGON",
+	ColumnTypeMySQLMultipoint:                   "MULTIPOINT",
+	ColumnTypeMySQLMultilinestring:              "MULTILINESTRING",
+	ColumnTypeMySQLMultipolygon:                 "MULTIPOLYGON",
+	ColumnTypeMySQLGeometrycollection:           "GEOMETRYCOLLECTION",
+	ColumnTypeSQLServerXml:                      "XML",
+	ColumnTypeSQLServerGeography:                "GEOGRAPHY",
+	ColumnTypeSQLServerGeometry:                 "GEOMETRY",
+	ColumnTypeSQLServerHierarchyid:              "HIERARCHYID",
+	ColumnTypeSQLServerUniqueidentifier:         "UNIQUEIDENTIFIER",
+	ColumnTypeSQLServerImage:                    "IMAGE",
+	ColumnTypeSQLServerNtext:                    "NTEXT",
+	ColumnTypeSQLServerSqlVariant:               "SQL_VARIANT",
+	ColumnTypeSQLServerTimestamp:                "TIMESTAMP",
+	ColumnTypeSQLServerMoney:                    "MONEY",
+	ColumnTypeSQLServerSmallmoney:               "SMALLMONEY",
+	ColumnTypeSQLServerDatetime2:                "DATETIME2",
+	ColumnTypeSQLServerDatetimeoffset:           "DATETIMEOFFSET",
+	ColumnTypeSQLServerSmalldatetime:            "SMALLDATETIME",
+	ColumnTypeCQLCounter:                        "COUNTER",
+	ColumnTypeCQLDuration:                       "DURATION",
+	ColumnTypeCQLInet:                           "INET",
+	ColumnTypeCQLList:                           "LIST",
+	ColumnTypeCQLMap:                            "MAP",
+	ColumnTypeCQLSet:                            "SET",
+	ColumnTypeCQLTuple:                          "TUPLE",
+	ColumnTypeCQLVector:                         "VECTOR",
+	ColumnTypeClickHouseLowCardinality:          "LowCardinality",
+	ColumnTypeClickHouseNullable:                "Nullable",
+	ColumnTypeClickHouseArray:                   "Array",
+	ColumnTypeClickHouseMap:                     "Map",
+	ColumnTypeClickHouseTuple:                   "Tuple",
+	ColumnTypeClickHouseNested:                  "Nested",
+	ColumnTypeClickHouseEnum8:                   "Enum8",
+	ColumnTypeClickHouseEnum16:                  "Enum16",
+	ColumnTypeClickHouseDate32:                  "Date32",
+	ColumnTypeClickHouseDateTime64:              "DateTime64",
+	ColumnTypeClickHouseIPv4:                    "IPv4",
+	ColumnTypeClickHouseIPv6:                    "IPv6",
+	ColumnTypeClickHouseObjectJson:              "Object('json')",
+	ColumnTypeClickHouseDecimal32:               "Decimal32",
+	ColumnTypeClickHouseDecimal64:               "Decimal64",
+	ColumnTypeClickHouseDecimal128:              "Decimal128",
+	ColumnTypeClickHouseDecimal256:              "Decimal256",
+	ColumnTypeClickHouseAggregateFunction:       "AggregateFunction",
+	ColumnTypeClickHouseSimpleAggregateFunction: "SimpleAggregateFunction",
+	ColumnTypePrestoRow:                         "ROW",
+	ColumnTypePrestoArray:                       "ARRAY",
+	ColumnTypePrestoMap:                         "MAP",
+	ColumnTypePrestoIntervalYearToMonth:         "INTERVAL YEAR TO MONTH",
+	ColumnTypePrestoIntervalDayToSecond:         "INTERVAL DAY TO SECOND",
+	ColumnTypePrestoIpaddress:                   "IPADDRESS",
+	ColumnTypePrestoGeometry:                    "GEOMETRY",
+	ColumnTypePrestoBingTile:                    "BING_TILE",
+	ColumnTypePrestoHyperloglog:                 "HYPERLOGLOG",
+	ColumnTypePrestoP4hyperloglog:               "P4HYPERLOGLOG",
+	ColumnTypePrestoQdigest:                     "QDIGEST",
+	ColumnTypePrestoTdigest:                     "TDIGEST",
+	ColumnTypePrestoBarcode:                     "BARCODE",
+	ColumnTypePrestoTimeWithTimezone:            "TIME WITH TIME ZONE",
+	ColumnTypePrestoTimestampWithTimezone:       "TIMESTAMP WITH TIME ZONE",
+	ColumnTypeOracleNclob:                       "NCLOB",
+	ColumnTypeOracleRaw:                         "RAW",
+	ColumnTypeOracleBinaryFloat:                 "BINARY_FLOAT",
+	ColumnTypeOracleBinaryDouble:                "BINARY_DOUBLE",
+	ColumnTypeOracleIntervalYearToMonth:         "INTERVAL YEAR TO MONTH",
+	ColumnTypeOracleIntervalDayToSecond:         "INTERVAL DAY TO SECOND",
+	ColumnTypeOracleUrowid:                      "UROWID",
+	ColumnTypeOracleAnydata:                     "ANYDATA",
+	ColumnTypeOracleAnytype:                     "ANYTYPE",
+	ColumnTypeOracleAnydataset:                  "ANYDATASET",
+	ColumnTypeOracleXmltype:                     "XMLTYPE",
+	ColumnTypeOracleUritype:                     "URITYPE",
+	ColumnTypeOracleDburitype:                   "DBURITYPE",
+	ColumnTypeOracleXdburitype:                  "XDBURITYPE",
+	ColumnTypeOracleHttpuritype:                 "HTTPURITYPE",
+	ColumnTypeOracleSdoGeometry:                 "SDO_GEOMETRY",
+	ColumnTypeOracleSdoTopoGeometry:             "SDO_TOPO_GEOMETRY",
+	ColumnTypeOracleSdoGeoraster:                "SDO_GEORASTER",
+	ColumnTypeInformixLvarchar:                  "LVARCHAR",
+	ColumnTypeInformixByte:                      "BYTE",
+	ColumnTypeInformixMoney:                     "MONEY",
+	ColumnTypeInformixSerial:                    "SERIAL",
+	ColumnTypeInformixSerial8:                   "SERIAL8",
+	ColumnTypeInformixBigserial:                 "BIGSERIAL",
+	ColumnTypeInformixClob:                      "CLOB",
+	ColumnTypeInformixInterval:                  "INTERVAL",
+	ColumnTypeInformixList:                      "LIST",
+	ColumnTypeInformixMultiset:                  "MULTISET",
+	ColumnTypeInformixSet:                       "SET",
+	ColumnTypeInformixRow:                       "ROW",
+	ColumnTypeMariaDBInet4:                      "INET4",
+	ColumnTypeMariaDBInet6:                      "INET6",
+	ColumnTypeMariaDBUuid:                       "UUID",
+	ColumnTypeMariaDBVector:                     "VECTOR",
+	ColumnTypeCockroachDBString:                 "STRING",
+	ColumnTypeCockroachDBBytes:                  "BYTES",
+	ColumnTypeCockroachDBInt2:                   "INT2",
+	ColumnTypeCockroachDBInt4:                   "INT4",
+	ColumnTypeCockroachDBInt8:                   "INT8",
+	ColumnTypeCockroachDBFloat4:                 "FLOAT4",
+	ColumnTypeCockroachDBFloat8:                 "FLOAT8",
+	ColumnTypeCockroachDBInet:                   "INET",
+	ColumnTypeCockroachDBInterval:               "INTERVAL",
+	ColumnTypeCockroachDBGeography:              "GEOGRAPHY",
+	ColumnTypeCockroachDBGeometry:               "GEOMETRY",
+	ColumnTypeCockroachDBBox2D:                  "BOX2D",
+	ColumnTypeCockroachDBOid:                    "OID",
+	ColumnTypeDuckDBHugeInt:                     "HUGEINT",
+	ColumnTypeDuckDBUHugeInt:                    "UHUGEINT",
+	ColumnTypeDuckDBUTinyInt:                    "UTINYINT",
+	ColumnTypeDuckDBUSmallInt:                   "USMALLINT",
+	ColumnTypeDuckDBUInteger:                    "UINTEGER",
+	ColumnTypeDuckDBUBigInt:                     "UBIGINT",
+	ColumnTypeDuckDBList:                        "LIST",
+	ColumnTypeDuckDBStruct:                      "STRUCT",
+	ColumnTypeDuckDBMap:                         "MAP",
+	ColumnTypeDuckDBUnion:                       "UNION",
+	ColumnTypeDuckDBInterval:                    "INTERVAL",
+	ColumnTypeDuckDBBitString:                   "BITSTRING",
+	ColumnTypeDuckDBTimestampNs:                 "TIMESTAMP_NS",
+	ColumnTypeDuckDBVarint:                      "VARINT",
+}
+
 func (ct ColumnType) String() string {
-	names := make([]string, 8014)
-	names[0] = "VARCHAR"
-	names[1] = "CHAR"
-	names[2] = "TEXT"
-	names[3] = "TINYINT"
-	names[4] = "SMALLINT"
-	names[5] = "INT"
-	names[6] = "BIGINT"
-	names[7] = "BOOLEAN"
-	names[8] = "REAL"
-	names[9] = "DOUBLE"
-	names[10] = "DECIMAL"
-	names[11] = "DATE"
-	names[12] = "TIME"
-	names[13] = "DATETIME"
-	names[14] = "TIMESTAMP"
-	names[15] = "BLOB"
-	names[16] = "JSON"
-	names[17] = "UUID"
-	names[18] = "BIT"
-	names[19] = "BINARY"
-	names[20] = "VARBINARY"
-	names[21] = "MONEY"
-	names[22] = "XML"
-	names[1000] = "JSONB"
-	names[1001] = "HSTORE"
-	names[1002] = "TSVECTOR"
-	names[1003] = "MONEY"
-	names[1004] = "INTERVAL"
-	names[1005] = "INET"
-	names[1006] = "MACADDR"
-	names[1007] = "MACADDR8"
-	names[1008] = "BIT"
-	names[1009] = "VARBIT"
-	names[1010] = "BOX"
-	names[1011] = "CIRCLE"
-	names[1012] = "LINE"
-	names[1013] = "LSEG"
-	names[1014] = "PATH"
-	names[1015] = "POLYGON"
-	names[1016] = "TSQUERY"
-	names[1017] = "JSONPATH"
-	names[1018] = "XML"
-	names[1019] = "ARRAY"
-	names[1020] = "RANGE"
-	names[1021] = "MULTIRANGE"
-	names[1022] = "PG_LSN"
-	names[1023] = "PG_SNAPSHOT"
-	names[2000] = "SET"
-	names[2001] = "ENUM"
-	names[2002] = "POINT"
-	names[2003] = "TINYTEXT"
-	names[2004] = "MEDIUMTEXT"
-	names[2005] = "LONGTEXT"
-	names[2006] = "TINYBLOB"
-	names[2007] = "MEDIUMBLOB"
-	names[2008] = "LONGBLOB"
-	names[2009] = "YEAR"
-	names[2010] = "GEOMETRY"
-	names[2011] = "LINESTRING"
-	names[2012] = "POLYGON"
-	names[2013] = "MULTIPOINT"
-	names[2014] = "MULTILINESTRING"
-	names[2015] = "MULTIPOLYGON"
-	names[2016] = "GEOMETRYCOLLECTION"
-	names[3000] = "XML"
-	names[3001] = "GEOGRAPHY"
-	names[3002] = "GEOMETRY"
-	names[3003] = "HIERARCHYID"
-	names[3004] = "UNIQUEIDENTIFIER"
-	names[3005] = "IMAGE"
-	names[3006] = "NTEXT"
-	names[3007] = "SQL_VARIANT"
-	names[3008] = "TIMESTAMP"
-	names[3009] = "MONEY"
-	names[3010] = "SMALLMONEY"
-	names[3011] = "DATETIME2"
-	names[3012] = "DATETIMEOFFSET"
-	names[3013] = "SMALLDATETIME"
-	names[4000] = "COUNTER"
-	names[4001] = "DURATION"
-	names[4002] = "INET"
-	names[4003] = "LIST"
-	names[4004] = "MAP"
-	names[4005] = "SET"
-	names[4006] = "TUPLE"
-	names[4007] = "VECTOR"
-	names[5000] = "LowCardinality"
-	names[5001] = "Nullable"
-	names[5002] = "Array"
-	names[5003] = "Map"
-	names[5004] = "Tuple"
-	names[5005] = "Nested"
-	names[5006] = "Enum8"
-	names[5007] = "Enum16"
-	names[5008] = "Date32"
-	names[5009] = "DateTime64"
-	names[5010] = "IPv4"
-	names[5011] = "IPv6"
-	names[5012] = "Object('json')"
-	names[5013] = "Decimal32"
-	names[5014] = "Decimal64"
-	names[5015] = "Decimal128"
-	names[5016] = "Decimal256"
-	names[5017] = "AggregateFunction"
-	names[5018] = "SimpleAggregateFunction"
-	names[6000] = "ROW"
-	names[6001] = "ARRAY"
-	names[6002] = "MAP"
-	names[6003] = "INTERVAL YEAR TO MONTH"
-	names[6004] = "INTERVAL DAY TO SECOND"
-	names[6005] = "IPADDRESS"
-	names[6006] = "GEOMETRY"
-	names[6007] = "BING_TILE"
-	names[6008] = "HYPERLOGLOG"
-	names[6009] = "P4HYPERLOGLOG"
-	names[6010] = "QDIGEST"
-	names[6011] = "TDIGEST"
-	names[6012] = "BARCODE"
-	names[6013] = "TIME WITH TIME ZONE"
-	names[6014] = "TIMESTAMP WITH TIME ZONE"
-	names[7000] = "NCLOB"
-	names[7001] = "RAW"
-	names[7002] = "BINARY_FLOAT"
-	names[7003] = "BINARY_DOUBLE"
-	names[7004] = "INTERVAL YEAR TO MONTH"
-	names[7005] = "INTERVAL DAY TO SECOND"
-	names[7006] = "UROWID"
-	names[7007] = "ANYDATA"
-	names[7008] = "ANYTYPE"
-	names[7009] = "ANYDATASET"
-	names[7010] = "XMLTYPE"
-	names[7011] = "URITYPE"
-	names[7012] = "DBURITYPE"
-	names[7013] = "XDBURITYPE"
-	names[7014] = "HTTPURITYPE"
-	names[7015] = "SDO_GEOMETRY"
-	names[7016] = "SDO_TOPO_GEOMETRY"
-	names[7017] = "SDO_GEORASTER"
-	names[8000] = "LVARCHAR"
-	names[8001] = "BYTE"
-	names[8002] = "MONEY"
-	names[8003] = "SERIAL"
-	names[8004] = "SERIAL8"
-	names[8005] = "BIGSERIAL"
-	names[8006] = "CLOB"
-	names[8007] = "INTERVAL"
-	names[8008] = "LIST"
-	names[8009] = "MULTISET"
-	names[8010] = "SET"
-	names[8011] = "ROW"
-	if int(ct) < len(names) && names[ct] != "" {
-		return names[ct]
+	if name, ok := columnTypeNames[ct]; ok {
+		return name
 	}
 	return "UNKNOWN"
 }
+
+// vendorFlavors returns the flavors a database specific type belongs to, nil for shared types.
+func (ct ColumnType) vendorFlavors() []flavors.Flavor {
+	switch {
+	case ct >= 11000:
+		return []flavors.Flavor{flavors.DuckDB}
+	case ct >= 10000:
+		return []flavors.Flavor{flavors.CockroachDB}
+	case ct >= 9000:
+		return []flavors.Flavor{flavors.MariaDB}
+	case ct >= 8000:
+		return []flavors.Flavor{flavors.Informix}
+	case ct >= 7000:
+		return []flavors.Flavor{flavors.Oracle}
+	case ct >= 6000:
+		return []flavors.Flavor{flavors.Presto}
+	case ct >= 5000:
+		return []flavors.Flavor{flavors.ClickHouse}
+	case ct >= 4000:
+		return []flavors.Flavor{flavors.CQL}
+	case ct >= 3000:
+		return []flavors.Flavor{flavors.SQLServer}
+	case ct >= 2000:
+		return []flavors.Flavor{flavors.MySQL, flavors.MariaDB}
+	case ct >= 1000:
+		return []flavors.Flavor{flavors.PostgreSQL, flavors.CockroachDB}
+	}
+	return nil
+}
+
+// checkVendorType warns when a column overrides its type with one specific to other databases,
+// e.g. ColumnTypeDuckDBHugeInt on PostgreSQL. The type is kept as given. Enums, collections and
+// counters are translated for each flavor instead.
+func checkVendorType(flavor flavors.Flavor, table string, col *Column[any]) {
+	vendors := col.AbstractType.vendorFlavors()
+	if col.Type == "" || vendors == nil || isEnum(col) || isCollection(col) || col.AbstractType == ColumnTypeCQLCounter {
+		return
+	}
+	var names []string
+	for _, vendor := range vendors {
+		if flavor.Base() == vendor {
+			return
+		}
+		names = append(names, vendor.String())
+	}
+	warnf("type %s of column %s.%s is specific to %s, not %s", col.Type, table, col.Name, strings.Join(names, " and "), flavor)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+func TestColumnTypeString(t *testing.T) {
+	tests := []struct {
+		ct   ColumnType
+		want string
+	}{
+		{ColumnTypeVarchar, "VARCHAR"},
+		{ColumnTypePostgresJsonb, "JSONB"},
+		{ColumnTypePostgresPgSnapshot, "PG_SNAPSHOT"},
+		{ColumnTypeMySQLGeometrycollection, "GEOMETRYCOLLECTION"},
+		{ColumnTypeDuckDBHugeInt, "HUGEINT"},
+		{ColumnTypeDuckDBVarint, "VARINT"},
+		{ColumnType(-1), "UNKNOWN"},
+		{ColumnType(11014), "UNKNOWN"},
+	}
+	for _, tt := range tests {
+		if got := tt.ct.String(); got != tt.want {
+			t.Errorf("ColumnType(%d).String() = %q, want %q", int(tt.ct), got, tt.want)
+		}
+	}
+}
+
+func TestVendorTypeWarning(t *testing.T) {
+	handler := WarningHandler
+	defer func() { WarningHandler = handler }()
+
+	tests := []struct {
+		flavor flavors.Flavor
+		col    *Column[any]
+		warn   bool
+	}{
+		{flavors.DuckDB, BigInt("id", WithType[int64](ColumnTypeDuckDBHugeInt)), false},
+		{flavors.PostgreSQL, BigInt("id", WithType[int64](ColumnTypeDuckDBHugeInt)), true},
+		{flavors.CockroachDB, Text("doc", WithType[string](ColumnTypePostgresJsonb)), false},
+		{flavors.MySQL, Text("doc", WithType[string](ColumnTypePostgresJsonb)), true},
+		{flavors.MySQL, Text("name", WithType[string]("CITEXT")), false},
+		{flavors.MySQL, Text("name", WithType[string](ColumnTypeText)), false},
+		{flavors.PostgreSQL, &Column[any]{Name: "status", AbstractType: ColumnTypeMySQLEnum, Type: "ENUM", EnumValues: []string{"active"}}, false},
+	}
+	for _, tt := range tests {
+		var warnings []string
+		WarningHandler = func(msg string) { warnings = append(warnings, msg) }
+		table := &Table{Name: "users", Columns: []*Column[any]{tt.col}}
+		table.columnDefinition(tt.flavor, tt.col)
+		if got := len(warnings) > 0; got != tt.warn {
+			t.Errorf("%s %s on %s: warned %t, want %t: %v", tt.col.Name, tt.col.Type, tt.flavor, got, tt.warn, warnings)
+		}
+	}
+}
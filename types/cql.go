@@ -0,0 +1,54 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// ClusteringColumn is a CQL clustering column and its sort order.
+type ClusteringColumn struct {
+	Column string
+	Desc   bool
+}
+
+// Asc clusters rows by column in ascending order.
+func Asc(column string) ClusteringColumn { return ClusteringColumn{Column: column} }
+
+// Desc clusters rows by column in descending order.
+func Desc(column string) ClusteringColumn { return ClusteringColumn{Column: column, Desc: true} }
+
+// cqlPrimaryKey renders the PRIMARY KEY ((partition key), clustering columns) element of a CQL table.
+func (t *Table) cqlPrimaryKey(flavor flavors.Flavor) string {
+	partition := make([]string, len(t.PartitionKey))
+	for i, column := range t.PartitionKey {
+		partition[i] = flavor.Quote(column)
+	}
+	key := []string{"(" + strings.Join(partition, ", ") + ")"}
+	for _, clustering := range t.ClusteringKey {
+		key = append(key, flavor.Quote(clustering.Column))
+	}
+	return "PRIMARY KEY (" + strings.Join(key, ", ") + ")"
+}
+
+// cqlOptions returns the WITH clause of a CQL table.
+func (t *Table) cqlOptions(flavor flavors.Flavor) []string {
+	var props []string
+	if len(t.ClusteringKey) > 0 {
+		order := make([]string, len(t.ClusteringKey))
+		for i, clustering := range t.ClusteringKey {
+			order[i] = flavor.Quote(clustering.Column) + " ASC"
+			if clustering.Desc {
+				order[i] = flavor.Quote(clustering.Column) + " DESC"
+			}
+		}
+		props = append(props, "CLUSTERING ORDER BY ("+strings.Join(order, ", ")+")")
+	}
+	if t.Comment != "" {
+		props = append(props, "comment = "+quoteLiteral(t.Comment))
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	return []string{"WITH " + strings.Join(props, " AND ")}
+}
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// SchemaObject is a database object that BuildCreateAll and BuildDropAll can create and drop:
+// a *Table, Extension, Sequence or Domain.
+type SchemaObject interface {
+	BuildCreate(flavor flavors.Flavor, opts ...CreateOption) string
+	BuildDrop(flavor flavors.Flavor, opts ...DropOption) string
+}
+
+// objectRank orders schema objects so that the ones tables depend on are created first.
+func objectRank(object SchemaObject) int {
+	switch object.(type) {
+	case Extension, *Extension:
+		return 0
+	case Sequence, *Sequence, Domain, *Domain:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// schemaPlan is the order in which BuildCreateAll creates a schema.
+type schemaPlan struct {
+	objects  []SchemaObject        // Extensions, sequences and domains, in creation order
+	tables   []*Table              // Tables, referenced tables first
+	others   []SchemaObject        // Other objects, created after the tables
+	deferred map[*Column[any]]bool // Foreign keys added once all tables exist
+}
+
+// planSchema orders the objects for creation. Tables are sorted so that referenced tables come
+// first, keeping the given order otherwise; foreign keys closing a cycle are deferred.
+func planSchema(flavor flavors.Flavor, objects []SchemaObject) schemaPlan {
+	plan := schemaPlan{deferred: map[*Column[any]]bool{}}
+	var tables []*Table
+	for rank := 0; rank <= 2; rank++ {
+		for _, object := range objects {
+			if objectRank(object) != rank {
+				continue
+			}
+			if table, ok := object.(*Table); ok {
+				tables = append(tables, table)
+			} else if rank < 2 {
+				plan.objects = append(plan.objects, object)
+			} else {
+				plan.others = append(plan.others, object)
+			}
+		}
+	}
+
+	// Foreign keys may name the referenced table with or without its schema
+	byName := map[string]*Table{}
+	for _, table := range tables {
+		byName[table.QualifiedName()] = table
+	}
+	for _, table := range tables {
+		if _, ok := byName[table.Name]; !ok {
+			byName[table.Name] = table
+		}
+	}
+
+	// SQLite accepts references to tables created later, so only cycles elsewhere need deferring
+	canDefer := supportsForeignKeys(flavor) && flavor.Base() != flavors.SQLite
+	const visiting, visited = 1, 2
+	state := map[*Table]int{}
+	var visit func(table *Table)
+	visit = func(table *Table) {
+		state[table] = visiting
+		for _, col := range table.Columns {
+			if col.References == nil {
+				continue
+			}
+			target, ok := byName[col.References.Table]
+			if !ok || target == table {
+				continue
+			}
+			switch state[target] {
+			case visiting:
+				if canDefer {
+					plan.deferred[col] = true
+				}
+			case 0:
+				visit(target)
+			}
+		}
+		state[table] = visited
+		plan.tables = append(plan.tables, table)
+	}
+	for _, table := range tables {
+		if state[table] == 0 {
+			visit(table)
+		}
+	}
+	return plan
+}
+
+// deferredColumns returns the columns of the table whose foreign keys are deferred.
+func (p schemaPlan) deferredColumns(table *Table) []*Column[any] {
+	var cols []*Column[any]
+	for _, col := range table.Columns {
+		if p.deferred[col] {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// BuildCreateAll builds the statements creating a whole schema, separated by ";\n": extensions,
+// sequences and domains first, then the tables with referenced tables before the tables
+// referencing them, then their indexes. Foreign keys forming a cycle are added after the indexes
+// with ALTER TABLE ADD CONSTRAINT, followed by any other objects in the given order.
+func BuildCreateAll(flavor flavors.Flavor, objects ...SchemaObject) string {
+	plan := planSchema(flavor, objects)
+	var stmts []string
+	for _, object := range plan.objects {
+		if sql := object.BuildCreate(flavor); sql != "" {
+			stmts = append(stmts, sql)
+		}
+	}
+	for _, table := range plan.tables {
+		stmts = append(stmts, table.buildCreate(flavor, plan.deferred, createOptions{})...)
+	}
+	for _, table := range plan.tables {
+		stmts = append(stmts, table.buildIndexes(flavor, createOptions{})...)
+	}
+	for _, table := range plan.tables {
+		for _, col := range plan.deferredColumns(table) {
+			stmts = append(stmts, table.buildAddForeignKey(flavor, col))
+		}
+	}
+	for _, object := range plan.others {
+		if sql := object.BuildCreate(flavor); sql != "" {
+			stmts = append(stmts, sql)
+		}
+	}
+	return strings.Join(stmts, ";\n")
+}
+
+// BuildDropAll builds the statements dropping a schema created by BuildCreateAll, in reverse
+// order, separated by ";\n". Deferred foreign keys are dropped first to break cycles.
+func BuildDropAll(flavor flavors.Flavor, objects ...SchemaObject) string {
+	plan := planSchema(flavor, objects)
+	var stmts []string
+	for i := len(plan.others) - 1; i >= 0; i-- {
+		if sql := plan.others[i].BuildDrop(flavor); sql != "" {
+			stmts = append(stmts, sql)
+		}
+	}
+	for _, table := range plan.tables {
+		for _, col := range plan.deferredColumns(table) {
+			stmts = append(stmts, table.buildDropForeignKey(flavor, col))
+		}
+	}
+	for i := len(plan.tables) - 1; i >= 0; i-- {
+		stmts = append(stmts, plan.tables[i].BuildDrop(flavor))
+	}
+	for i := len(plan.objects) - 1; i >= 0; i-- {
+		if sql := plan.objects[i].BuildDrop(flavor); sql != "" {
+			stmts = append(stmts, sql)
+		}
+	}
+	return strings.Join(stmts, ";\n")
+}
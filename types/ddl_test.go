@@ -0,0 +1,52 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+func TestPlanSchemaCycles(t *testing.T) {
+	users := &Table{Name: "users", Columns: []*Column[any]{
+		BigInt("id"),
+		BigInt("team_id", WithReferences[int64]("teams", "id")),
+	}}
+	teams := &Table{Name: "teams", Columns: []*Column[any]{
+		BigInt("id"),
+		BigInt("owner_id", WithReferences[int64]("users", "id")),
+	}}
+	posts := &Table{Name: "posts", Columns: []*Column[any]{
+		BigInt("id"),
+		BigInt("author_id", WithReferences[int64]("users", "id")),
+	}}
+
+	tests := []struct {
+		flavor   flavors.Flavor
+		objects  []SchemaObject
+		tables   []string
+		deferred []string
+	}{
+		{flavors.PostgreSQL, []SchemaObject{posts, users, teams}, []string{"teams", "users", "posts"}, []string{"teams.owner_id"}},
+		{flavors.PostgreSQL, []SchemaObject{teams, users}, []string{"users", "teams"}, []string{"users.team_id"}},
+		{flavors.PostgreSQL, []SchemaObject{posts, users}, []string{"users", "posts"}, nil},
+		{flavors.SQLite, []SchemaObject{users, teams}, []string{"teams", "users"}, nil},
+		{flavors.ClickHouse, []SchemaObject{users, teams}, []string{"teams", "users"}, nil},
+	}
+	for _, tt := range tests {
+		plan := planSchema(tt.flavor, tt.objects)
+		var tables, deferred []string
+		for _, table := range plan.tables {
+			tables = append(tables, table.Name)
+			for _, col := range plan.deferredColumns(table) {
+				deferred = append(deferred, table.Name+"."+col.Name)
+			}
+		}
+		if !reflect.DeepEqual(tables, tt.tables) {
+			t.Errorf("%s: tables %v, want %v", tt.flavor, tables, tt.tables)
+		}
+		if !reflect.DeepEqual(deferred, tt.deferred) {
+			t.Errorf("%s: deferred %v, want %v", tt.flavor, deferred, tt.deferred)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// TableDiff lists the changes between two versions of a table. Columns, checks and indexes are
+// matched by name, so a rename shows up as a drop and an add.
+type TableDiff struct {
+	From, To          *Table
+	AddedColumns      []*Column[any]
+	DroppedColumns    []*Column[any]
+	AlteredColumns    []*Column[any] // Columns of To whose type differs from From
+	AddedPrimaryKey   []string       // Primary key of To, when it differs from the one of From
+	DroppedPrimaryKey []string       // Primary key of From, when it differs from the one of To
+	AddedChecks       []Check
+	DroppedChecks     []Check
+	AddedIndexes      []Index
+	DroppedIndexes    []Index
+}
+
+// DiffTables compares two versions of a table.
+func DiffTables(from, to *Table) TableDiff {
+	diff := TableDiff{From: from, To: to}
+	fromColumns := map[string]*Column[any]{}
+	for _, col := range from.Columns {
+		fromColumns[col.Name] = col
+	}
+	toColumns := map[string]bool{}
+	for _, col := range to.Columns {
+		toColumns[col.Name] = true
+		if old, ok := fromColumns[col.Name]; !ok {
+			diff.AddedColumns = append(diff.AddedColumns, col)
+		} else if !sameColumnType(old, col) {
+			diff.AlteredColumns = append(diff.AlteredColumns, col)
+		}
+	}
+	for _, col := range from.Columns {
+		if !toColumns[col.Name] {
+			diff.DroppedColumns = append(diff.DroppedColumns, col)
+		}
+	}
+
+	if !reflect.DeepEqual(from.PrimaryKey, to.PrimaryKey) {
+		diff.DroppedPrimaryKey, diff.AddedPrimaryKey = from.PrimaryKey, to.PrimaryKey
+	}
+
+	fromChecks, toChecks := map[string]Check{}, map[string]Check{}
+	for _, check := range from.CheckConstraints() {
+		fromChecks[check.Name] = check
+	}
+	for _, check := range to.CheckConstraints() {
+		toChecks[check.Name] = check
+		if old, ok := fromChecks[check.Name]; !ok || old != check {
+			diff.AddedChecks = append(diff.AddedChecks, check)
+		}
+	}
+	for _, check := range from.CheckConstraints() {
+		if current, ok := toChecks[check.Name]; !ok || current != check {
+			diff.DroppedChecks = append(diff.DroppedChecks, check)
+		}
+	}
+
+	fromIndexes, toIndexes := map[string]Index{}, map[string]Index{}
+	for _, index := range from.Indexes {
+		fromIndexes[from.indexName(index)] = index
+	}
+	for _, index := range to.Indexes {
+		toIndexes[to.indexName(index)] = index
+		if old, ok := fromIndexes[to.indexName(index)]; !ok || !reflect.DeepEqual(old.Columns, index.Columns) || old.Unique != index.Unique {
+			diff.AddedIndexes = append(diff.AddedIndexes, index)
+		}
+	}
+	for _, index := range from.Indexes {
+		if current, ok := toIndexes[from.indexName(index)]; !ok || !reflect.DeepEqual(current.Columns, index.Columns) || current.Unique != index.Unique {
+			diff.DroppedIndexes = append(diff.DroppedIndexes, index)
+		}
+	}
+	return diff
+}
+
+// sameColumnType reports whether two columns have the same SQL type.
+func sameColumnType(a, b *Column[any]) bool {
+	return a.Type == b.Type && a.AbstractType == b.AbstractType && a.Domain == b.Domain &&
+		reflect.DeepEqual(a.Length, b.Length) && reflect.DeepEqual(a.Precision, b.Precision) && reflect.DeepEqual(a.Scale, b.Scale) &&
+		reflect.DeepEqual(a.EnumValues, b.EnumValues) && a.ElementType == b.ElementType && a.KeyType == b.KeyType &&
+		a.AutoIncrement == b.AutoIncrement && a.LowCardinality == b.LowCardinality && a.Nullable == b.Nullable
+}
+
+// IsEmpty reports whether the two versions of the table are the same.
+func (d TableDiff) IsEmpty() bool {
+	return len(d.AddedColumns)+len(d.DroppedColumns)+len(d.AlteredColumns)+len(d.AddedPrimaryKey)+len(d.DroppedPrimaryKey)+
+		len(d.AddedChecks)+len(d.DroppedChecks)+len(d.AddedIndexes)+len(d.DroppedIndexes) == 0
+}
+
+// alteredColumns returns the altered columns whose SQL type differs in the given flavor, e.g. not
+// VARCHAR columns of another length on SQLite, where both are TEXT.
+func (d TableDiff) alteredColumns(flavor flavors.Flavor) []*Column[any] {
+	var altered []*Column[any]
+	for _, col := range d.AlteredColumns {
+		for _, old := range d.From.Columns {
+			if old.Name == col.Name && d.From.columnType(flavor, old) != d.To.columnType(flavor, col) {
+				altered = append(altered, col)
+			}
+		}
+	}
+	return altered
+}
+
+// needsRebuild reports whether the flavor can only apply the diff by rebuilding the table. Neither
+// SQLite nor DuckDB can add or drop constraints, including primary keys; SQLite also cannot change
+// column types, add columns with non-constant defaults or foreign keys, or drop columns with a
+// constraint or trigger.
+func (d TableDiff) needsRebuild(flavor flavors.Flavor) bool {
+	if len(d.AddedChecks) > 0 || len(d.DroppedChecks) > 0 || len(d.AddedPrimaryKey) > 0 || len(d.DroppedPrimaryKey) > 0 {
+		return true
+	}
+	if flavor.Base() != flavors.SQLite {
+		return false
+	}
+	if len(d.alteredColumns(flavor)) > 0 {
+		return true
+	}
+	for _, col := range d.AddedColumns {
+		if col.DefaultExpr != nil || col.Generated != nil || col.References != nil || col.OnUpdateNow {
+			return true
+		}
+	}
+	// Indexes on dropped columns are dropped beforehand
+	for _, col := range d.DroppedColumns {
+		if col.References != nil || col.OnUpdateNow {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildAlter builds the statements turning From into To, separated by ";\n". On SQLite and DuckDB,
+// changes that cannot be made in place rebuild the table instead, see BuildRebuild.
+func (d TableDiff) BuildAlter(flavor flavors.Flavor) string {
+	if (flavor.Base() == flavors.SQLite || flavor.Base() == flavors.DuckDB) && d.needsRebuild(flavor) {
+		return d.BuildRebuild(flavor)
+	}
+	var stmts []string
+	for _, index := range d.DroppedIndexes {
+		if sql := d.From.BuildDropIndex(flavor, index); sql != "" {
+			stmts = append(stmts, sql)
+		}
+	}
+	if supportsCheckConstraints(flavor) {
+		for _, check := range d.DroppedChecks {
+			stmts = append(stmts, d.From.BuildDropCheck(flavor, check.Name))
+		}
+	}
+	if len(d.DroppedPrimaryKey) > 0 && supportsPrimaryKeys(flavor) {
+		stmts = append(stmts, d.From.BuildDropPrimaryKey(flavor))
+	}
+	for _, col := range d.DroppedColumns {
+		stmts = append(stmts, d.To.BuildDropColumn(flavor, col.Name))
+	}
+	for _, col := range d.AddedColumns {
+		stmts = append(stmts, d.To.BuildAddColumn(flavor, col))
+	}
+	for _, col := range d.alteredColumns(flavor) {
+		if sql := d.To.BuildAlterColumnType(flavor, col); sql != "" {
+			stmts = append(stmts, sql)
+		}
+	}
+	if len(d.AddedPrimaryKey) > 0 && supportsPrimaryKeys(flavor) {
+		stmts = append(stmts, d.To.BuildAddPrimaryKey(flavor))
+	}
+	if supportsCheckConstraints(flavor) {
+		for _, check := range d.AddedChecks {
+			stmts = append(stmts, d.To.BuildAddCheck(flavor, check))
+		}
+	}
+	for _, index := range d.AddedIndexes {
+		if sql := d.To.BuildCreateIndex(flavor, index); sql != "" {
+			stmts = append(stmts, sql)
+		}
+	}
+	return strings.Join(stmts, ";\n")
+}
+
+// BuildRebuild builds the SQLite or DuckDB table rebuild turning From into To: a new table is
+// created and filled with the kept columns, the old table is dropped and the new one renamed, then
+// indexes and triggers are recreated. On SQLite, foreign key enforcement is off during the rebuild
+// and the foreign keys are checked before committing.
+func (d TableDiff) BuildRebuild(flavor flavors.Flavor) string {
+	if flavor.Base() != flavors.SQLite && flavor.Base() != flavors.DuckDB {
+		panic("table rebuilds are only needed for SQLite and DuckDB")
+	}
+	newName := flavor.Quote(d.To.Name + "_new")
+	if d.To.Schema != "" {
+		newName = flavor.Quote(d.To.Schema) + "." + newName
+	}
+	fromColumns := map[string]bool{}
+	for _, col := range d.From.Columns {
+		fromColumns[col.Name] = true
+	}
+	var kept []string
+	for _, col := range d.To.Columns {
+		if fromColumns[col.Name] && col.Generated == nil {
+			kept = append(kept, flavor.Quote(col.Name))
+		}
+	}
+	columns := strings.Join(kept, ", ")
+
+	sqlite := flavor.Base() == flavors.SQLite
+	var stmts []string
+	if sqlite {
+		stmts = append(stmts, "PRAGMA foreign_keys = OFF")
+	}
+	stmts = append(stmts,
+		"BEGIN TRANSACTION",
+		d.To.buildCreateTable(flavor, newName, nil, createOptions{}),
+		"INSERT INTO "+newName+" ("+columns+") SELECT "+columns+" FROM "+d.From.quotedName(flavor),
+		"DROP TABLE "+d.From.quotedName(flavor),
+		"ALTER TABLE "+newName+" RENAME TO "+flavor.Quote(d.To.Name),
+	)
+	stmts = append(stmts, d.To.buildIndexes(flavor, createOptions{})...)
+	stmts = append(stmts, d.To.buildOnUpdateTriggers(flavor, createOptions{})...)
+	if !sqlite {
+		return strings.Join(append(stmts, "COMMIT"), ";\n")
+	}
+	return strings.Join(append(stmts, "PRAGMA foreign_key_check", "COMMIT", "PRAGMA foreign_keys = ON"), ";\n")
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+func TestNeedsRebuild(t *testing.T) {
+	from := &Table{Name: "users", Columns: []*Column[any]{
+		BigInt("id"),
+		Text("name"),
+	}}
+	tests := []struct {
+		name   string
+		to     *Table
+		sqlite bool
+		duckdb bool
+	}{
+		{"added column", &Table{Name: "users", Columns: []*Column[any]{
+			BigInt("id"), Text("name"), Text("email"),
+		}}, false, false},
+		{"altered type", &Table{Name: "users", Columns: []*Column[any]{
+			BigInt("id"), BigInt("name"),
+		}}, true, false},
+		{"added foreign key", &Table{Name: "users", Columns: []*Column[any]{
+			BigInt("id"), Text("name"), BigInt("team_id", WithReferences[int64]("teams", "id")),
+		}}, true, false},
+		{"added check", &Table{Name: "users", Columns: []*Column[any]{
+			BigInt("id"), Text("name"),
+		}, Checks: []Check{{Name: "users_name_check", Expr: "name <> ''"}}}, true, true},
+		{"added primary key", &Table{Name: "users", Columns: []*Column[any]{
+			BigInt("id"), Text("name"),
+		}, PrimaryKey: []string{"id"}}, true, true},
+	}
+	for _, tt := range tests {
+		diff := DiffTables(from, tt.to)
+		if got := diff.needsRebuild(flavors.SQLite); got != tt.sqlite {
+			t.Errorf("%s: needsRebuild(SQLite) = %t, want %t", tt.name, got, tt.sqlite)
+		}
+		if got := diff.needsRebuild(flavors.DuckDB); got != tt.duckdb {
+			t.Errorf("%s: needsRebuild(DuckDB) = %t, want %t", tt.name, got, tt.duckdb)
+		}
+	}
+}
+
+func TestBuildRebuild(t *testing.T) {
+	from := &Table{Name: "users", Columns: []*Column[any]{BigInt("id"), Text("name")}}
+	to := &Table{Name: "users", Columns: []*Column[any]{BigInt("id"), Text("email")}, PrimaryKey: []string{"id"}}
+	diff := DiffTables(from, to)
+
+	tests := []struct {
+		flavor  flavors.Flavor
+		pragmas bool
+	}{
+		{flavors.SQLite, true},
+		{flavors.DuckDB, false},
+	}
+	for _, tt := range tests {
+		sql := diff.BuildRebuild(tt.flavor)
+		if got := strings.Contains(sql, "PRAGMA foreign_keys = OFF"); got != tt.pragmas {
+			t.Errorf("%s: PRAGMA in rebuild = %t, want %t:\n%s", tt.flavor, got, tt.pragmas, sql)
+		}
+		insert := "INSERT INTO " + tt.flavor.Quote("users_new") + " (" + tt.flavor.Quote("id") + ") SELECT " + tt.flavor.Quote("id") + " FROM"
+		if !strings.Contains(sql, insert) {
+			t.Errorf("%s: rebuild does not copy the kept columns:\n%s", tt.flavor, sql)
+		}
+		if !strings.HasSuffix(sql, "COMMIT") && !strings.HasSuffix(sql, "PRAGMA foreign_keys = ON") {
+			t.Errorf("%s: rebuild does not commit:\n%s", tt.flavor, sql)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("BuildRebuild(PostgreSQL) did not panic")
+		}
+	}()
+	diff.BuildRebuild(flavors.PostgreSQL)
+}
+
+func TestBuildAlterUnsupportedChanges(t *testing.T) {
+	handler := WarningHandler
+	defer func() { WarningHandler = handler }()
+	WarningHandler = nil
+
+	tests := []struct {
+		name     string
+		flavor   flavors.Flavor
+		from, to *Table
+		want     string
+	}{
+		{"CQL type change", flavors.CQL,
+			&Table{Name: "users", Columns: []*Column[any]{BigInt("id"), Text("name")}, PartitionKey: []string{"id"}},
+			&Table{Name: "users", Columns: []*Column[any]{BigInt("id"), BigInt("name")}, PartitionKey: []string{"id"}},
+			""},
+		{"ClickHouse dropped index", flavors.ClickHouse,
+			&Table{Name: "users", Columns: []*Column[any]{BigInt("id")}, Indexes: []Index{{Columns: []string{"id"}}}},
+			&Table{Name: "users", Columns: []*Column[any]{BigInt("id")}},
+			""},
+		{"PostgreSQL primary key", flavors.PostgreSQL,
+			&Table{Name: "users", Columns: []*Column[any]{BigInt("id"), Text("email")}, PrimaryKey: []string{"id"}},
+			&Table{Name: "users", Columns: []*Column[any]{BigInt("id"), Text("email")}, PrimaryKey: []string{"email"}},
+			`ALTER TABLE "users" DROP CONSTRAINT "users_pkey";` + "\n" + `ALTER TABLE "users" ADD CONSTRAINT "users_pkey" PRIMARY KEY ("email")`},
+		{"MySQL primary key", flavors.MySQL,
+			&Table{Name: "users", Columns: []*Column[any]{BigInt("id")}, PrimaryKey: []string{"id"}},
+			&Table{Name: "users", Columns: []*Column[any]{BigInt("id")}},
+			"ALTER TABLE `users` DROP PRIMARY KEY"},
+	}
+	for _, tt := range tests {
+		if got := DiffTables(tt.from, tt.to).BuildAlter(tt.flavor); got != tt.want {
+			t.Errorf("%s: BuildAlter = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// Expr is an SQL expression used in place of a literal, such as a column default.
+// Portable functions created with Now, CurrentDate and RandomUUID are translated per flavor;
+// expressions created with SQL are written verbatim.
+type Expr struct {
+	Func string // Portable function name: "now", "current_date", "random_uuid" or "nextval"
+	Raw  string // Verbatim SQL when Func is empty, the sequence name for "nextval"
+}
+
+// SQL returns an expression written verbatim, e.g. SQL("gen_random_uuid()").
+func SQL(raw string) Expr { return Expr{Raw: raw} }
+
+// Now returns the current timestamp, e.g. CURRENT_TIMESTAMP or now().
+func Now() Expr { return Expr{Func: "now"} }
+
+// CurrentDate returns the current date, e.g. CURRENT_DATE or today().
+func CurrentDate() Expr { return Expr{Func: "current_date"} }
+
+// RandomUUID returns a newly generated random UUID, e.g. gen_random_uuid() or NEWID().
+func RandomUUID() Expr { return Expr{Func: "random_uuid"} }
+
+// NextVal returns the next value of the named sequence, e.g. nextval('order_seq') or NEXT VALUE FOR order_seq.
+func NextVal(sequence string) Expr { return Expr{Func: "nextval", Raw: sequence} }
+
+// SQL renders the expression for the given flavor. RandomUUID renders as empty, with a warning, on
+// flavors that cannot generate UUIDs, such as Informix; column and domain defaults are then left out.
+func (e Expr) SQL(flavor flavors.Flavor) string {
+	switch e.Func {
+	case "":
+		return e.Raw
+	case "now":
+		switch flavor.Base() {
+		case flavors.ClickHouse:
+			return "now()"
+		case flavors.Informix:
+			return "CURRENT YEAR TO FRACTION(3)"
+		default:
+			return "CURRENT_TIMESTAMP"
+		}
+	case "current_date":
+		switch flavor.Base() {
+		case flavors.MySQL:
+			return "(CURRENT_DATE)"
+		case flavors.SQLServer:
+			return "CAST(GETDATE() AS DATE)"
+		case flavors.Oracle:
+			return "TRUNC(SYSDATE)"
+		case flavors.Informix:
+			return "TODAY"
+		case flavors.ClickHouse:
+			return "today()"
+		default:
+			return "CURRENT_DATE"
+		}
+	case "random_uuid":
+		switch flavor.Base() {
+		case flavors.MySQL:
+			return "(UUID())"
+		case flavors.MariaDB:
+			return "UUID()"
+		case flavors.PostgreSQL, flavors.CockroachDB, flavors.DuckDB:
+			return "gen_random_uuid()"
+		case flavors.SQLite:
+			// Random version 4 UUID in 8-4-4-4-12 form, with the variant nibble drawn from 8, 9, a and b
+			return "(lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || " +
+				"substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))))"
+		case flavors.SQLServer:
+			return "NEWID()"
+		case flavors.Oracle:
+			return "SYS_GUID()"
+		case flavors.ClickHouse:
+			return "generateUUIDv4()"
+		case flavors.CQL, flavors.Presto:
+			return "uuid()"
+		default:
+			warnf("random UUID generation ignored: not supported by %s", flavor)
+			return ""
+		}
+	case "nextval":
+		switch flavor.Base() {
+		case flavors.PostgreSQL, flavors.CockroachDB, flavors.DuckDB:
+			return "nextval(" + quoteLiteral(e.Raw) + ")"
+		case flavors.SQLServer, flavors.MariaDB:
+			return "NEXT VALUE FOR " + flavor.QuoteQualified(e.Raw)
+		case flavors.Oracle, flavors.Informix:
+			return flavor.QuoteQualified(e.Raw) + ".NEXTVAL"
+		default:
+			panic(fmt.Sprintf("sequences not supported for flavor: %s", flavor))
+		}
+	default:
+		panic(fmt.Sprintf("unknown SQL function: %s", e.Func))
+	}
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// Index is a secondary index on columns of a table.
+type Index struct {
+	Name    string // Defaults to <table>_<columns>_idx
+	Columns []string
+	Unique  bool
+}
+
+// indexName returns the name of the index, derived from its columns if unnamed.
+func (t *Table) indexName(index Index) string {
+	if index.Name != "" {
+		return index.Name
+	}
+	return t.Name + "_" + strings.Join(index.Columns, "_") + "_idx"
+}
+
+// BuildCreateIndex builds the CREATE INDEX statement for an index of the table. Indexes the
+// flavor cannot create issue a warning and return an empty statement.
+func (t *Table) BuildCreateIndex(flavor flavors.Flavor, index Index) string {
+	return t.buildCreateIndex(flavor, index, createOptions{})
+}
+
+// buildCreateIndex builds the CREATE INDEX statement, with IF NOT EXISTS where supported.
+func (t *Table) buildCreateIndex(flavor flavors.Flavor, index Index, opts createOptions) string {
+	name := t.indexName(index)
+	if reason := unsupportedIndex(flavor, index); reason != "" {
+		warnf("index %s on table %s ignored: %s", name, t.Name, reason)
+		return ""
+	}
+	columns := make([]string, len(index.Columns))
+	for i, col := range index.Columns {
+		columns[i] = flavor.Quote(col)
+	}
+	sql := "CREATE INDEX "
+	if index.Unique {
+		sql = "CREATE UNIQUE INDEX "
+	}
+	if opts.ifNotExists {
+		switch flavor.Base() {
+		case flavors.PostgreSQL, flavors.SQLite, flavors.CQL, flavors.Informix, flavors.MariaDB, flavors.CockroachDB, flavors.DuckDB:
+			sql += "IF NOT EXISTS "
+		default:
+			warnf("IF NOT EXISTS on index %s ignored: not supported by %s", name, flavor)
+		}
+	}
+	table := t.quotedName(flavor)
+	if flavor.Base() == flavors.SQLite && t.Schema != "" {
+		// SQLite qualifies the index instead of the table
+		sql += flavor.Quote(t.Schema) + "." + flavor.Quote(name)
+		table = flavor.Quote(t.Name)
+	} else {
+		sql += flavor.Quote(name)
+	}
+	return sql + " ON " + table + " (" + strings.Join(columns, ", ") + ")"
+}
+
+// unsupportedIndex returns why the flavor cannot create the index, or nothing if it can.
+func unsupportedIndex(flavor flavors.Flavor, index Index) string {
+	switch flavor.Base() {
+	case flavors.ClickHouse, flavors.Presto:
+		return "not supported by " + flavor.String()
+	case flavors.CQL:
+		if index.Unique || len(index.Columns) != 1 {
+			return "CQL indexes are single-column and not unique"
+		}
+	}
+	return ""
+}
+
+// buildIndexes builds the CREATE INDEX statements for the indexes of the table.
+func (t *Table) buildIndexes(flavor flavors.Flavor, opts createOptions) []string {
+	var stmts []string
+	for _, index := range t.Indexes {
+		if sql := t.buildCreateIndex(flavor, index, opts); sql != "" {
+			stmts = append(stmts, sql)
+		}
+	}
+	return stmts
+}
+
+// BuildDropIndex builds the DROP INDEX statement for an index of the table, or an empty statement
+// for indexes the flavor cannot create.
+func (t *Table) BuildDropIndex(flavor flavors.Flavor, index Index) string {
+	if unsupportedIndex(flavor, index) != "" {
+		return ""
+	}
+	name := flavor.Quote(t.indexName(index))
+	switch flavor.Base() {
+	case flavors.MySQL, flavors.SQLServer, flavors.MariaDB:
+		return "DROP INDEX " + name + " ON " + t.quotedName(flavor)
+	case flavors.CockroachDB:
+		return "DROP INDEX " + t.quotedName(flavor) + "@" + name
+	case flavors.SQLite, flavors.PostgreSQL, flavors.Oracle, flavors.DuckDB:
+		if t.Schema != "" {
+			name = flavor.Quote(t.Schema) + "." + name
+		}
+	}
+	return "DROP INDEX " + name
+}
@@ -0,0 +1,226 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// Extension is a PostgreSQL extension, e.g. Extension{Name: "pgcrypto"}.
+type Extension struct {
+	Name   string
+	Schema string // Schema the extension objects are installed in
+}
+
+// Sequence is a standalone sequence, used for column defaults through NextVal.
+type Sequence struct {
+	Name      string
+	Schema    string
+	Start     *int64
+	Increment *int64
+	MinValue  *int64
+	MaxValue  *int64
+	Cache     *int64
+	Cycle     bool
+	OwnedBy   string // Column the sequence is dropped with on PostgreSQL, e.g. "orders.number"
+}
+
+// Domain is a PostgreSQL domain: a named base type with an optional default and constraints.
+type Domain struct {
+	Name      string
+	Schema    string
+	Type      ColumnType
+	Length    *int
+	Precision *int
+	Scale     *int
+	Default   *Expr
+	NotNull   bool
+	Check     string // CHECK constraint on VALUE, e.g. "VALUE ~ '^[^@]+@[^@]+$'"
+}
+
+// qualifiedName joins a schema-qualified object name.
+func qualifiedName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+// quoteQualifiedName quotes an object name and, if any, its schema, keeping dots in either intact.
+func quoteQualifiedName(flavor flavors.Flavor, schema, name string) string {
+	if schema == "" {
+		return flavor.Quote(name)
+	}
+	return flavor.Quote(schema) + "." + flavor.Quote(name)
+}
+
+// BuildCreate builds the CREATE EXTENSION statement, always with IF NOT EXISTS. Other flavors than PostgreSQL have no
+// extensions: a warning is issued and the statement is empty.
+func (e Extension) BuildCreate(flavor flavors.Flavor, opts ...CreateOption) string {
+	if flavor.Base() != flavors.PostgreSQL {
+		warnf("extension %s ignored: not supported by %s", e.Name, flavor)
+		return ""
+	}
+	sql := "CREATE EXTENSION IF NOT EXISTS " + flavor.Quote(e.Name)
+	if e.Schema != "" {
+		sql += " SCHEMA " + flavor.Quote(e.Schema)
+	}
+	return sql
+}
+
+// BuildDrop builds the DROP EXTENSION statement, empty on other flavors than PostgreSQL.
+func (e Extension) BuildDrop(flavor flavors.Flavor, opts ...DropOption) string {
+	if flavor.Base() != flavors.PostgreSQL {
+		return ""
+	}
+	ifExists, cascade := dropClauses(flavor, "extension "+e.Name, false, opts)
+	return "DROP EXTENSION " + ifExists + flavor.Quote(e.Name) + cascade
+}
+
+// QualifiedName returns the sequence name prefixed with its schema, if any.
+func (s Sequence) QualifiedName() string { return qualifiedName(s.Schema, s.Name) }
+
+// NextVal returns the next value of the sequence, for use with WithDefaultExpr.
+func (s Sequence) NextVal() Expr { return NextVal(s.QualifiedName()) }
+
+// supportsSequences reports whether the flavor has sequences.
+func supportsSequences(flavor flavors.Flavor) bool {
+	switch flavor.Base() {
+	case flavors.PostgreSQL, flavors.SQLServer, flavors.Oracle, flavors.Informix, flavors.MariaDB, flavors.CockroachDB, flavors.DuckDB:
+		return true
+	default:
+		return false
+	}
+}
+
+// BuildCreate builds the CREATE SEQUENCE statement. Flavors without sequences issue a warning
+// and return an empty statement.
+func (s Sequence) BuildCreate(flavor flavors.Flavor, opts ...CreateOption) string {
+	if !supportsSequences(flavor) {
+		warnf("sequence %s ignored: not supported by %s", s.Name, flavor)
+		return ""
+	}
+	return s.buildCreate(flavor, newCreateOptions(flavor, "sequence "+s.Name, opts))
+}
+
+// buildCreate builds the CREATE SEQUENCE statement with the given options.
+func (s Sequence) buildCreate(flavor flavors.Flavor, o createOptions) string {
+	sql := "CREATE SEQUENCE "
+	switch {
+	case o.temporary && flavor.Base() != flavors.SQLServer && flavor.Base() != flavors.Oracle && flavor.Base() != flavors.Informix:
+		sql = "CREATE TEMPORARY SEQUENCE "
+	case o.unlogged:
+		sql = "CREATE UNLOGGED SEQUENCE "
+	case o.temporary:
+		warnf("temporary sequence %s created as a regular one: not supported by %s", s.Name, flavor)
+	}
+	if o.ifNotExists && flavor.Base() != flavors.SQLServer {
+		sql += "IF NOT EXISTS "
+	}
+	sql += quoteQualifiedName(flavor, s.Schema, s.Name)
+	options := []struct {
+		keyword string
+		value   *int64
+	}{
+		{"START WITH", s.Start},
+		{"INCREMENT BY", s.Increment},
+		{"MINVALUE", s.MinValue},
+		{"MAXVALUE", s.MaxValue},
+		{"CACHE", s.Cache},
+	}
+	for _, option := range options {
+		if option.value == nil {
+			continue
+		}
+		if option.keyword == "CACHE" && flavor.Base() == flavors.DuckDB {
+			warnf("cache of sequence %s ignored: not supported by %s", s.Name, flavor)
+			continue
+		}
+		sql += fmt.Sprintf(" %s %d", option.keyword, *option.value)
+	}
+	if s.Cycle {
+		sql += " CYCLE"
+	}
+	if s.OwnedBy != "" {
+		if flavor.Base() == flavors.PostgreSQL || flavor.Base() == flavors.CockroachDB {
+			// The column follows the last dot and the table may be schema-qualified; NONE has no dot
+			if dot := strings.LastIndex(s.OwnedBy, "."); dot >= 0 {
+				sql += " OWNED BY " + flavor.QuoteQualified(s.OwnedBy[:dot]) + "." + flavor.Quote(s.OwnedBy[dot+1:])
+			} else {
+				sql += " OWNED BY " + s.OwnedBy
+			}
+		} else {
+			warnf("owner of sequence %s ignored: not supported by %s", s.Name, flavor)
+		}
+	}
+	if o.ifNotExists && flavor.Base() == flavors.SQLServer {
+		sql = "IF OBJECT_ID(N'" + strings.ReplaceAll(quoteQualifiedName(flavor, s.Schema, s.Name), "'", "''") + "', N'SO') IS NULL " + sql
+	}
+	return sql
+}
+
+// BuildDrop builds the DROP SEQUENCE statement, empty on flavors without sequences.
+func (s Sequence) BuildDrop(flavor flavors.Flavor, opts ...DropOption) string {
+	if !supportsSequences(flavor) {
+		return ""
+	}
+	ifExists, cascade := dropClauses(flavor, "sequence "+s.Name, false, opts)
+	return dropStatement(flavor, "SEQUENCE", ifExists, quoteQualifiedName(flavor, s.Schema, s.Name), "SO") + cascade
+}
+
+// QualifiedName returns the domain name prefixed with its schema, if any.
+func (d Domain) QualifiedName() string { return qualifiedName(d.Schema, d.Name) }
+
+// Column creates a column of the domain type, e.g. Email.Column("email"). Flavors without domains
+// use the base type instead. Use DomainColumn to pass typed options such as WithDefault.
+func (d Domain) Column(name string, args ...ColumnOption[any]) *Column[any] {
+	return DomainColumn(d, name, args...)
+}
+
+// DomainColumn creates a column of the domain type whose options are typed by the domain's Go type,
+// e.g. DomainColumn(Email, "email", WithDefault("nobody@example.com")).
+func DomainColumn[T any](d Domain, name string, args ...ColumnOption[T]) *Column[any] {
+	column := createType(name, d.Type, args...)
+	column.Domain = d.QualifiedName()
+	column.Length, column.Precision, column.Scale = d.Length, d.Precision, d.Scale
+	return column
+}
+
+// BuildCreate builds the CREATE DOMAIN statement; with IfNotExists, which CREATE DOMAIN lacks,
+// an existing domain is skipped in a DO block. Other flavors than PostgreSQL have no domains:
+// a warning is issued and the statement is empty.
+func (d Domain) BuildCreate(flavor flavors.Flavor, opts ...CreateOption) string {
+	if flavor.Base() != flavors.PostgreSQL {
+		warnf("domain %s ignored: not supported by %s", d.Name, flavor)
+		return ""
+	}
+	o := newCreateOptions(flavor, "domain "+d.Name, opts)
+	if o.temporary || o.unlogged {
+		warnf("options of domain %s ignored: only IfNotExists applies to domains", d.Name)
+	}
+	base := &Column[any]{AbstractType: d.Type, Length: d.Length, Precision: d.Precision, Scale: d.Scale}
+	sql := "CREATE DOMAIN " + quoteQualifiedName(flavor, d.Schema, d.Name) + " AS " + getTypeWithAuto(flavor, base)
+	if d.Default != nil {
+		sql += " DEFAULT " + d.Default.SQL(flavor)
+	}
+	if d.NotNull {
+		sql += " NOT NULL"
+	}
+	if d.Check != "" {
+		sql += " CHECK (" + d.Check + ")"
+	}
+	if o.ifNotExists {
+		sql = "DO $$ BEGIN " + sql + "; EXCEPTION WHEN duplicate_object THEN NULL; END $$"
+	}
+	return sql
+}
+
+// BuildDrop builds the DROP DOMAIN statement, empty on other flavors than PostgreSQL.
+func (d Domain) BuildDrop(flavor flavors.Flavor, opts ...DropOption) string {
+	if flavor.Base() != flavors.PostgreSQL {
+		return ""
+	}
+	ifExists, cascade := dropClauses(flavor, "domain "+d.Name, false, opts)
+	return "DROP DOMAIN " + ifExists + quoteQualifiedName(flavor, d.Schema, d.Name) + cascade
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golshani-mhd/grizzle-kit/flavors"
+)
+
+// primaryKeyName returns the name of the primary key constraint of the table, <table>_pkey.
+func (t *Table) primaryKeyName() string { return t.Name + "_pkey" }
+
+// supportsPrimaryKeys reports whether the flavor declares primary keys with Table.PrimaryKey. CQL
+// tables use PartitionKey and ClusteringKey, ClickHouse tables the ClickHouse options, and Presto
+// has no primary keys.
+func supportsPrimaryKeys(flavor flavors.Flavor) bool {
+	switch flavor.Base() {
+	case flavors.CQL, flavors.ClickHouse, flavors.Presto:
+		return false
+	default:
+		return true
+	}
+}
+
+// primaryKeyDefinition renders the primary key of the table as a named table element.
+func (t *Table) primaryKeyDefinition(flavor flavors.Flavor) string {
+	columns := make([]string, len(t.PrimaryKey))
+	for i, col := range t.PrimaryKey {
+		columns[i] = flavor.Quote(col)
+	}
+	if flavor.Base() == flavors.Informix {
+		// Informix names constraints after their definition
+		return "PRIMARY KEY (" + strings.Join(columns, ", ") + ") CONSTRAINT " + flavor.Quote(t.primaryKeyName())
+	}
+	return "CONSTRAINT " + flavor.Quote(t.primaryKeyName()) + " PRIMARY KEY (" + strings.Join(columns, ", ") + ")"
+}
+
+// buildPrimaryKey renders the primary key element of CREATE TABLE, or nothing. SQLite declares
+// auto-increment columns as INTEGER PRIMARY KEY, so a table with one has no other primary key.
+func (t *Table) buildPrimaryKey(flavor flavors.Flavor) string {
+	if len(t.PrimaryKey) == 0 {
+		return ""
+	}
+	if !supportsPrimaryKeys(flavor) {
+		warnf("primary key of table %s ignored: not supported by %s", t.Name, flavor)
+		return ""
+	}
+	if flavor.Base() == flavors.SQLite {
+		for _, col := range t.Columns {
+			if !col.AutoIncrement {
+				continue
+			}
+			if len(t.PrimaryKey) != 1 || t.PrimaryKey[0] != col.Name {
+				warnf("primary key of table %s ignored: the auto-increment column %s is the primary key on %s", t.Name, col.Name, flavor)
+			}
+			return ""
+		}
+	}
+	return t.primaryKeyDefinition(flavor)
+}
+
+// BuildAddPrimaryKey builds the ALTER TABLE statement adding the primary key of the table.
+func (t *Table) BuildAddPrimaryKey(flavor flavors.Flavor) string {
+	if !supportsPrimaryKeys(flavor) {
+		panic(fmt.Sprintf("primary keys not supported for flavor: %s", flavor))
+	}
+	switch flavor.Base() {
+	case flavors.SQLite:
+		panic("SQLite cannot add constraints to an existing table")
+	case flavors.DuckDB:
+		panic("DuckDB cannot add constraints to an existing table")
+	}
+	return t.alterTable(flavor) + " ADD " + t.primaryKeyDefinition(flavor)
+}
+
+// BuildDropPrimaryKey builds the ALTER TABLE statement dropping the primary key of the table.
+func (t *Table) BuildDropPrimaryKey(flavor flavors.Flavor) string {
+	if !supportsPrimaryKeys(flavor) {
+		panic(fmt.Sprintf("primary keys not supported for flavor: %s", flavor))
+	}
+	switch flavor.Base() {
+	case flavors.SQLite:
+		panic("SQLite cannot drop constraints from an existing table")
+	case flavors.DuckDB:
+		panic("DuckDB cannot drop constraints from an existing table")
+	case flavors.MySQL, flavors.MariaDB:
+		return t.alterTable(flavor) + " DROP PRIMARY KEY"
+	default:
+		return t.alterTable(flavor) + " DROP CONSTRAINT " + flavor.Quote(t.primaryKeyName())
+	}
+}
@@ -0,0 +1,37 @@
+package types
+
+// DeletedFilter selects which rows of a soft-delete table generated Select helpers return.
+type DeletedFilter int
+
+const (
+	ExcludeDeleted  DeletedFilter = iota // Default: rows whose soft-delete column is NULL
+	IncludeDeleted                       // All rows
+	OnlyDeletedRows                      // Rows whose soft-delete column is set
+)
+
+// SelectOptions configures generated Select helpers.
+type SelectOptions struct {
+	Deleted DeletedFilter
+}
+
+// SelectOption is a function to configure a generated Select helper.
+type SelectOption func(*SelectOptions)
+
+// WithDeleted includes soft-deleted rows.
+func WithDeleted() SelectOption {
+	return func(o *SelectOptions) { o.Deleted = IncludeDeleted }
+}
+
+// OnlyDeleted returns soft-deleted rows only.
+func OnlyDeleted() SelectOption {
+	return func(o *SelectOptions) { o.Deleted = OnlyDeletedRows }
+}
+
+// NewSelectOptions applies opts to the default SelectOptions.
+func NewSelectOptions(opts ...SelectOption) SelectOptions {
+	var o SelectOptions
+	for _, setOption := range opts {
+		setOption(&o)
+	}
+	return o
+}
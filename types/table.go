@@ -1,26 +1,88 @@
 package types
 
 import (
-	"bytes"
 	"fmt"
-	"reflect"
+	"log"
 	"strings"
-	"time"
 
 	"github.com/golshani-mhd/grizzle-kit/flavors"
-	"github.com/golshani-mhd/grizzle-kit/mapping"
 )
 
 // Table represents a database table.
 type Table struct {
-	Name    string
-	Columns []*Column[any]
+	Name        string
+	Schema      string // Namespace of the table, e.g. a PostgreSQL or SQL Server schema
+	Columns     []*Column[any]
+	PrimaryKey  []string // Columns of the primary key, named <table>_pkey; CQL uses PartitionKey instead
+	Checks      []Check
+	Indexes     []Index
+	SoftDelete  string // Column set to the deletion time instead of deleting rows, e.g. "deleted_at"
+	Comment     string
+	Engine      string   // MySQL storage engine or ClickHouse table engine, e.g. "InnoDB" or MergeTree()
+	Charset     string   // MySQL default character set
+	Collate     string   // MySQL default collation
+	Tablespace  string   // Tablespace, or filegroup on SQL Server and dbspace on Informix
+	OrderBy     []string // ClickHouse sorting key expressions
+	PartitionBy string   // ClickHouse partition key expression
+	ClickHouse  *ClickHouseOptions
+	// CQL primary key: rows are distributed by the partition key columns and sorted
+	// within a partition by the clustering columns
+	PartitionKey  []string
+	ClusteringKey []ClusteringColumn
+}
+
+// QualifiedName returns the table name prefixed with its schema, if any.
+func (t *Table) QualifiedName() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// quotedName returns the table name, prefixed with its schema if any, quoted for the given flavor.
+func (t *Table) quotedName(flavor flavors.Flavor) string {
+	if t.Schema == "" {
+		return flavor.Quote(t.Name)
+	}
+	return flavor.Quote(t.Schema) + "." + flavor.Quote(t.Name)
+}
+
+// Check is a named CHECK constraint.
+type Check struct {
+	Name string
+	Expr string
+}
+
+// WarningHandler receives warnings about schema features a flavor cannot enforce.
+// It logs by default; set it to nil to discard warnings.
+var WarningHandler = func(msg string) { log.Printf("grizzle-kit: warning: %s", msg) }
+
+// warnf formats a warning and passes it to WarningHandler.
+func warnf(format string, args ...any) {
+	if WarningHandler != nil {
+		WarningHandler(fmt.Sprintf(format, args...))
+	}
+}
+
+// TableRef is a table expression usable in FROM and JOIN clauses, such as a generated Schema or one of its aliases.
+type TableRef interface {
+	TableName() string
+	Alias() string
+	String() string
+}
+
+// columnSQLType returns the SQL type of the column: its Type if set, otherwise the flavor's type for its
+// abstract type.
+func columnSQLType(flavor flavors.Flavor, col *Column[any]) string {
+	if col.Type != "" {
+		return col.Type
+	}
+	return flavor.Dialect().ColumnType(int(col.AbstractType), col.Length, col.Precision, col.Scale)
 }
 
 // getTypeWithAuto returns the SQL type with auto-increment if applicable.
 func getTypeWithAuto(flavor flavors.Flavor, col *Column[any]) string {
-	mappingFlavor := mapping.Flavor(flavor)
-	sqlType := mapping.GetSQLType(mappingFlavor, col)
+	sqlType := columnSQLType(flavor, col)
 	if !col.AutoIncrement {
 		return sqlType
 	}
@@ -35,104 +97,621 @@ func getTypeWithAuto(flavor flavors.Flavor, col *Column[any]) string {
 	if !found {
 		panic("auto-increment only supported for integer types")
 	}
-	switch flavor {
+	return flavor.Dialect().AutoIncrement(int(col.AbstractType), sqlType)
+}
+
+// formatDefault formats the default value as SQL string.
+func formatDefault(flavor flavors.Flavor, v any) string {
+	return flavor.Dialect().FormatDefault(v)
+}
+
+// supportsForeignKeys reports whether the flavor enforces foreign key constraints.
+func supportsForeignKeys(flavor flavors.Flavor) bool {
+	switch flavor.Base() {
+	case flavors.CQL, flavors.ClickHouse, flavors.Presto:
+		return false
+	default:
+		return true
+	}
+}
+
+// quoteLiteral quotes a string as an SQL literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// supportsCheckConstraints reports whether the flavor enforces CHECK constraints. MySQL parses
+// but ignores them before 8.0.16.
+func supportsCheckConstraints(flavor flavors.Flavor) bool {
+	switch flavor.Base() {
+	case flavors.CQL, flavors.Presto:
+		return false
 	case flavors.MySQL:
-		return sqlType + " AUTO_INCREMENT"
+		return !flavor.Before("8.0.16")
+	default:
+		return true
+	}
+}
+
+// isEnum reports whether the column was declared with Enum.
+func isEnum(col *Column[any]) bool {
+	return col.AbstractType == ColumnTypeMySQLEnum && len(col.EnumValues) > 0
+}
+
+// enumTypeName returns the name of the PostgreSQL type backing an enum column.
+func (t *Table) enumTypeName(col *Column[any]) string {
+	return t.Name + "_" + col.Name
+}
+
+// quotedEnumTypeName returns the name of the PostgreSQL type backing an enum column, in the schema of the table.
+func (t *Table) quotedEnumTypeName(flavor flavors.Flavor, col *Column[any]) string {
+	if t.Schema == "" {
+		return flavor.Quote(t.enumTypeName(col))
+	}
+	return flavor.Quote(t.Schema) + "." + flavor.Quote(t.enumTypeName(col))
+}
+
+// enumLiterals returns the allowed values of an enum column as SQL literals.
+func enumLiterals(col *Column[any]) []string {
+	values := make([]string, len(col.EnumValues))
+	for i, v := range col.EnumValues {
+		values[i] = quoteLiteral(v)
+	}
+	return values
+}
+
+// enumType returns the SQL type of an enum column, with a CHECK constraint for flavors without native enums.
+func (t *Table) enumType(flavor flavors.Flavor, col *Column[any]) string {
+	values := enumLiterals(col)
+	switch flavor.Base() {
+	case flavors.MySQL, flavors.MariaDB, flavors.DuckDB:
+		return "ENUM(" + strings.Join(values, ", ") + ")"
+	case flavors.PostgreSQL, flavors.CockroachDB:
+		return t.quotedEnumTypeName(flavor, col)
+	case flavors.ClickHouse:
+		enumType := ColumnTypeClickHouseEnum8
+		if len(values) > 127 {
+			enumType = ColumnTypeClickHouseEnum16
+		}
+		for i := range values {
+			values[i] += fmt.Sprintf(" = %d", i+1)
+		}
+		return enumType.String() + "(" + strings.Join(values, ", ") + ")"
+	}
+	sqlType := columnSQLType(flavor, &Column[any]{AbstractType: ColumnTypeVarchar, Length: col.Length})
+	if supportsCheckConstraints(flavor) {
+		sqlType += " CHECK (" + flavor.Quote(col.Name) + " IN (" + strings.Join(values, ", ") + "))"
+	}
+	return sqlType
+}
+
+// buildEnumTypes builds the CREATE TYPE statements for the enum columns of the table on PostgreSQL
+// and CockroachDB. With IfNotExists, which CREATE TYPE lacks on PostgreSQL, existing types are
+// skipped in a DO block.
+func (t *Table) buildEnumTypes(flavor flavors.Flavor, opts createOptions) []string {
+	if flavor.Base() != flavors.PostgreSQL && flavor.Base() != flavors.CockroachDB {
+		return nil
+	}
+	var stmts []string
+	for _, col := range t.Columns {
+		if !isEnum(col) {
+			continue
+		}
+		sql := "CREATE TYPE " + t.quotedEnumTypeName(flavor, col) + " AS ENUM (" + strings.Join(enumLiterals(col), ", ") + ")"
+		if opts.ifNotExists && flavor.Base() == flavors.CockroachDB {
+			sql = "CREATE TYPE IF NOT EXISTS " + strings.TrimPrefix(sql, "CREATE TYPE ")
+		} else if opts.ifNotExists {
+			sql = "DO $$ BEGIN " + sql + "; EXCEPTION WHEN duplicate_object THEN NULL; END $$"
+		}
+		stmts = append(stmts, sql)
+	}
+	return stmts
+}
+
+// generatedDefinition renders the definition of a generated column from its plain definition def.
+func generatedDefinition(flavor flavors.Flavor, t *Table, col *Column[any], def string) string {
+	gen := col.Generated
+	kind := gen.Kind
+	switch flavor.Base() {
 	case flavors.PostgreSQL:
-		switch col.AbstractType {
-		case ColumnTypeSmallInt:
-			return "SMALLSERIAL"
-		case ColumnTypeInt:
-			return "SERIAL"
-		case ColumnTypeBigInt:
-			return "BIGSERIAL"
-		default:
-			return sqlType + " GENERATED BY DEFAULT AS IDENTITY"
+		if kind == Virtual {
+			warnf("virtual column %s.%s stored instead: not supported by %s", t.Name, col.Name, flavor)
+			kind = Stored
+		}
+	case flavors.Oracle, flavors.DuckDB:
+		if kind == Stored {
+			warnf("stored column %s.%s made virtual instead: not supported by %s", t.Name, col.Name, flavor)
+			kind = Virtual
 		}
-	case flavors.SQLite:
-		return "INTEGER PRIMARY KEY AUTOINCREMENT"
 	case flavors.SQLServer:
-		return sqlType + " IDENTITY(1,1)"
-	case flavors.Oracle:
-		return sqlType + " GENERATED ALWAYS AS IDENTITY"
-	case flavors.Informix:
-		switch col.AbstractType {
-		case ColumnTypeInt:
-			return "SERIAL"
-		case ColumnTypeBigInt:
-			return "BIGSERIAL"
-		default:
-			return sqlType
+		// Computed columns have no declared type
+		def = flavor.Quote(col.Name) + " AS (" + gen.Expr + ")"
+		if kind == Stored {
+			def += " PERSISTED"
 		}
-	case flavors.CQL, flavors.ClickHouse, flavors.Presto:
-		panic(fmt.Sprintf("auto-increment not supported for flavor: %s", flavor))
+		return def
+	case flavors.ClickHouse:
+		if kind == Stored {
+			return def + " MATERIALIZED " + gen.Expr
+		}
+		return def + " ALIAS " + gen.Expr
+	case flavors.MySQL, flavors.SQLite, flavors.MariaDB, flavors.CockroachDB:
 	default:
-		panic(fmt.Sprintf("unsupported flavor for auto-increment: %s", flavor))
+		warnf("generated column %s.%s created as a plain column: not supported by %s", t.Name, col.Name, flavor)
+		return def
 	}
+	return def + " GENERATED ALWAYS AS (" + gen.Expr + ") " + kind.String()
 }
 
-// formatDefault formats the default value as SQL string.
-func formatDefault(flavor flavors.Flavor, v any) string {
-	if v == nil {
-		return "NULL"
-	}
-	rv := reflect.ValueOf(v)
-	kind := rv.Kind()
-	if kind >= reflect.Int && kind <= reflect.Int64 {
-		return fmt.Sprint(rv.Int())
-	}
-	if kind >= reflect.Uint && kind <= reflect.Uint64 {
-		return fmt.Sprint(rv.Uint())
-	}
-	if kind == reflect.Float32 || kind == reflect.Float64 {
-		return fmt.Sprint(rv.Float())
-	}
-	if kind == reflect.Bool {
-		b := rv.Bool()
-		switch flavor {
-		case flavors.PostgreSQL, flavors.CQL, flavors.ClickHouse, flavors.Presto, flavors.Oracle, flavors.Informix:
-			if b {
-				return "TRUE"
+// CheckConstraints returns the CHECK constraints of the table, including the ones declared on
+// columns with WithCheck, which are named <table>_<column>_check.
+func (t *Table) CheckConstraints() []Check {
+	var checks []Check
+	for _, col := range t.Columns {
+		if col.Check != "" {
+			checks = append(checks, Check{Name: t.Name + "_" + col.Name + "_check", Expr: col.Check})
+		}
+	}
+	return append(checks, t.Checks...)
+}
+
+// checkDefinition renders a named CHECK constraint as a table element.
+func checkDefinition(flavor flavors.Flavor, check Check) string {
+	if flavor.Base() == flavors.Informix {
+		// Informix names constraints after their definition
+		return "CHECK (" + check.Expr + ") CONSTRAINT " + flavor.Quote(check.Name)
+	}
+	return "CONSTRAINT " + flavor.Quote(check.Name) + " CHECK (" + check.Expr + ")"
+}
+
+// BuildAddCheck builds the ALTER TABLE statement adding a CHECK constraint to the table.
+func (t *Table) BuildAddCheck(flavor flavors.Flavor, check Check) string {
+	if !supportsCheckConstraints(flavor) {
+		panic(fmt.Sprintf("CHECK constraints not supported for flavor: %s", flavor))
+	}
+	switch flavor.Base() {
+	case flavors.SQLite:
+		panic("SQLite cannot add constraints to an existing table")
+	case flavors.DuckDB:
+		panic("DuckDB cannot add constraints to an existing table")
+	}
+	return "ALTER TABLE " + t.quotedName(flavor) + " ADD " + checkDefinition(flavor, check)
+}
+
+// BuildDropCheck builds the ALTER TABLE statement dropping the named CHECK constraint.
+func (t *Table) BuildDropCheck(flavor flavors.Flavor, name string) string {
+	if !supportsCheckConstraints(flavor) {
+		panic(fmt.Sprintf("CHECK constraints not supported for flavor: %s", flavor))
+	}
+	switch flavor.Base() {
+	case flavors.SQLite:
+		panic("SQLite cannot drop constraints from an existing table")
+	case flavors.DuckDB:
+		panic("DuckDB cannot drop constraints from an existing table")
+	case flavors.MySQL:
+		return "ALTER TABLE " + t.quotedName(flavor) + " DROP CHECK " + flavor.Quote(name)
+	default:
+		return "ALTER TABLE " + t.quotedName(flavor) + " DROP CONSTRAINT " + flavor.Quote(name)
+	}
+}
+
+// HasNativeOnUpdate reports whether the database keeps WithOnUpdateNow columns current by itself,
+// natively on MySQL, MariaDB and CockroachDB and through the triggers created by BuildCreate on
+// PostgreSQL and SQLite.
+func HasNativeOnUpdate(flavor flavors.Flavor) bool {
+	switch flavor.Base() {
+	case flavors.MySQL, flavors.PostgreSQL, flavors.SQLite, flavors.MariaDB, flavors.CockroachDB:
+		return true
+	default:
+		return false
+	}
+}
+
+// buildOnUpdateTriggers builds the triggers refreshing WithOnUpdateNow columns on PostgreSQL and SQLite.
+// With IfNotExists, PostgreSQL replaces existing triggers and SQLite keeps them.
+func (t *Table) buildOnUpdateTriggers(flavor flavors.Flavor, opts createOptions) []string {
+	var stmts []string
+	table := t.quotedName(flavor)
+	for _, col := range t.Columns {
+		if !col.OnUpdateNow {
+			continue
+		}
+		name := t.Name + "_" + col.Name
+		column := flavor.Quote(col.Name)
+		switch flavor.Base() {
+		case flavors.PostgreSQL:
+			function := flavor.Quote(name + "_on_update")
+			if t.Schema != "" {
+				function = flavor.Quote(t.Schema) + "." + function
+			}
+			stmts = append(stmts, "CREATE OR REPLACE FUNCTION "+function+"() RETURNS TRIGGER AS $$ BEGIN NEW."+column+" = CURRENT_TIMESTAMP; RETURN NEW; END; $$ LANGUAGE plpgsql")
+			if opts.ifNotExists {
+				stmts = append(stmts, "DROP TRIGGER IF EXISTS "+flavor.Quote(name+"_on_update")+" ON "+table)
+			}
+			execute := "EXECUTE FUNCTION "
+			if flavor.Before("11") {
+				execute = "EXECUTE PROCEDURE " // EXECUTE FUNCTION is PostgreSQL 11+
 			}
-			return "FALSE"
+			stmts = append(stmts, "CREATE TRIGGER "+flavor.Quote(name+"_on_update")+" BEFORE UPDATE ON "+table+" FOR EACH ROW "+execute+function+"()")
+		case flavors.SQLite:
+			trigger := "CREATE TRIGGER "
+			if opts.ifNotExists {
+				trigger += "IF NOT EXISTS "
+			}
+			// The WHEN clause leaves explicitly written values alone and stops the trigger from re-firing
+			stmts = append(stmts, trigger+flavor.Quote(name+"_on_update")+" AFTER UPDATE ON "+table+
+				" FOR EACH ROW WHEN NEW."+column+" IS OLD."+column+
+				" BEGIN UPDATE "+table+" SET "+column+" = CURRENT_TIMESTAMP WHERE rowid = NEW.rowid; END")
+		}
+	}
+	return stmts
+}
+
+// supportsInlineComments reports whether the flavor declares comments within CREATE TABLE.
+func supportsInlineComments(flavor flavors.Flavor) bool {
+	switch flavor.Base() {
+	case flavors.MySQL, flavors.ClickHouse, flavors.Presto, flavors.MariaDB:
+		return true
+	default:
+		return false
+	}
+}
+
+// tableOptions returns the clauses following the column definitions of CREATE TABLE.
+func (t *Table) tableOptions(flavor flavors.Flavor) []string {
+	var opts []string
+	switch flavor.Base() {
+	case flavors.MySQL, flavors.MariaDB:
+		if t.Engine != "" {
+			opts = append(opts, "ENGINE="+t.Engine)
+		}
+		if t.Charset != "" {
+			opts = append(opts, "DEFAULT CHARSET="+t.Charset)
+		}
+		if t.Collate != "" {
+			opts = append(opts, "COLLATE="+t.Collate)
+		}
+		if t.Comment != "" {
+			opts = append(opts, "COMMENT="+quoteLiteral(t.Comment))
+		}
+	case flavors.ClickHouse:
+		opts = t.clickHouseOptions()
+		if t.Comment != "" {
+			opts = append(opts, "COMMENT "+quoteLiteral(t.Comment))
+		}
+	case flavors.Presto:
+		if t.Comment != "" {
+			opts = append(opts, "COMMENT "+quoteLiteral(t.Comment))
+		}
+	case flavors.CQL:
+		opts = t.cqlOptions(flavor)
+	}
+	if t.Tablespace != "" {
+		switch flavor.Base() {
+		case flavors.MySQL, flavors.PostgreSQL, flavors.Oracle:
+			opts = append(opts, "TABLESPACE "+flavor.Quote(t.Tablespace))
+		case flavors.SQLServer:
+			opts = append(opts, "ON "+flavor.Quote(t.Tablespace))
+		case flavors.Informix:
+			opts = append(opts, "IN "+t.Tablespace)
 		default:
-			if b {
-				return "1"
+			warnf("tablespace of table %s ignored: not supported by %s", t.Name, flavor)
+		}
+	}
+	if (len(t.PartitionKey) > 0 || len(t.ClusteringKey) > 0) && flavor.Base() != flavors.CQL {
+		warnf("partition and clustering keys of table %s ignored: not supported by %s", t.Name, flavor)
+	}
+	if t.Engine != "" && flavor.Base() != flavors.MySQL && flavor.Base() != flavors.MariaDB && flavor.Base() != flavors.ClickHouse {
+		warnf("engine of table %s ignored: not supported by %s", t.Name, flavor)
+	}
+	if (t.Charset != "" || t.Collate != "") && flavor.Base() != flavors.MySQL && flavor.Base() != flavors.MariaDB {
+		warnf("charset and collation of table %s ignored: not supported by %s", t.Name, flavor)
+	}
+	if (t.PartitionBy != "" || len(t.OrderBy) > 0 || t.ClickHouse != nil) && flavor.Base() != flavors.ClickHouse {
+		warnf("ClickHouse options of table %s ignored: not supported by %s", t.Name, flavor)
+	}
+	return opts
+}
+
+// buildComments builds the statements commenting the table and its columns on flavors
+// without inline comments.
+func (t *Table) buildComments(flavor flavors.Flavor) []string {
+	var stmts []string
+	table := t.quotedName(flavor)
+	switch flavor.Base() {
+	case flavors.PostgreSQL, flavors.Oracle, flavors.CockroachDB, flavors.DuckDB:
+		if t.Comment != "" {
+			stmts = append(stmts, "COMMENT ON TABLE "+table+" IS "+quoteLiteral(t.Comment))
+		}
+		for _, col := range t.Columns {
+			if col.Comment != "" {
+				stmts = append(stmts, "COMMENT ON COLUMN "+table+"."+flavor.Quote(col.Name)+" IS "+quoteLiteral(col.Comment))
+			}
+		}
+	case flavors.SQLServer:
+		// Comments are MS_Description extended properties
+		schema := t.Schema
+		if schema == "" {
+			schema = "dbo"
+		}
+		property := "EXEC sys.sp_addextendedproperty @name = N'MS_Description', @value = N%s, @level0type = N'SCHEMA', @level0name = N%s, @level1type = N'TABLE', @level1name = N%s"
+		if t.Comment != "" {
+			stmts = append(stmts, fmt.Sprintf(property, quoteLiteral(t.Comment), quoteLiteral(schema), quoteLiteral(t.Name)))
+		}
+		for _, col := range t.Columns {
+			if col.Comment != "" {
+				stmts = append(stmts, fmt.Sprintf(property+", @level2type = N'COLUMN', @level2name = N%s", quoteLiteral(col.Comment), quoteLiteral(schema), quoteLiteral(t.Name), quoteLiteral(col.Name)))
+			}
+		}
+	case flavors.CQL:
+		for _, col := range t.Columns {
+			if col.Comment != "" {
+				warnf("comment on column %s.%s ignored: not supported by %s", t.Name, col.Name, flavor)
+			}
+		}
+	case flavors.SQLite, flavors.Informix:
+		if t.Comment != "" {
+			warnf("comment on table %s ignored: not supported by %s", t.Name, flavor)
+		}
+		for _, col := range t.Columns {
+			if col.Comment != "" {
+				warnf("comment on column %s.%s ignored: not supported by %s", t.Name, col.Name, flavor)
+			}
+		}
+	}
+	return stmts
+}
+
+// CreateOption modifies a CREATE TABLE statement.
+type CreateOption int
+
+const (
+	IfNotExists CreateOption = iota + 1 // Leave an existing table, and its types, triggers and indexes, alone
+	Temporary                           // Create a table dropped at the end of the session
+	Unlogged                            // Skip the PostgreSQL write-ahead log
+	OrReplace                           // Replace an existing table, on ClickHouse, MariaDB and DuckDB
+)
+
+// createOptions are the CreateOptions supported by a flavor.
+type createOptions struct {
+	ifNotExists, temporary, unlogged, orReplace bool
+}
+
+// newCreateOptions collects the options of a CREATE statement. Options the flavor does not
+// support issue a warning and are ignored.
+func newCreateOptions(flavor flavors.Flavor, object string, opts []CreateOption) createOptions {
+	var o createOptions
+	for _, opt := range opts {
+		switch opt {
+		case IfNotExists:
+			if flavor.Base() == flavors.Oracle {
+				warnf("IF NOT EXISTS on %s ignored: not supported by %s", object, flavor)
+			} else {
+				o.ifNotExists = true
+			}
+		case Temporary:
+			if flavor.Base() == flavors.CQL || flavor.Base() == flavors.Presto {
+				warnf("temporary %s created as a regular one: not supported by %s", object, flavor)
+			} else {
+				o.temporary = true
+			}
+		case Unlogged:
+			if flavor.Base() == flavors.PostgreSQL {
+				o.unlogged = true
+			} else {
+				warnf("UNLOGGED on %s ignored: not supported by %s", object, flavor)
+			}
+		case OrReplace:
+			switch flavor.Base() {
+			case flavors.ClickHouse, flavors.MariaDB, flavors.DuckDB:
+				o.orReplace = true
+			default:
+				warnf("OR REPLACE on %s ignored: not supported by %s", object, flavor)
 			}
-			return "0"
 		}
 	}
-	if kind == reflect.String {
-		escaped := strings.Replace(rv.String(), "'", "''", -1)
-		return "'" + escaped + "'"
+	if o.orReplace && o.ifNotExists {
+		warnf("IF NOT EXISTS on %s ignored: replaced by OR REPLACE", object)
+		o.ifNotExists = false
 	}
-	if t, ok := v.(time.Time); ok {
-		return "'" + t.Format("2006-01-02 15:04:05") + "'"
+	if o.temporary && o.unlogged {
+		warnf("UNLOGGED on %s ignored: temporary tables are never logged", object)
+		o.unlogged = false
 	}
-	if b, ok := v.([]byte); ok {
-		var buf bytes.Buffer
-		buf.WriteString("X'")
-		for _, byteVal := range b {
-			fmt.Fprintf(&buf, "%02X", byteVal)
+	return o
+}
+
+// hasTempSchema reports whether temporary tables are created in a schema of their own, so that
+// they cannot be qualified with the schema of the table.
+func hasTempSchema(flavor flavors.Flavor) bool {
+	switch flavor.Base() {
+	case flavors.PostgreSQL, flavors.SQLServer, flavors.CockroachDB, flavors.DuckDB:
+		return true
+	default:
+		return false
+	}
+}
+
+// BuildCreate builds the CREATE TABLE SQL for the given flavor, e.g. BuildCreate(flavors.PostgreSQL,
+// IfNotExists). Statements the table depends on, such as enum types on PostgreSQL, precede it
+// and comments, triggers and indexes follow it, separated by ";\n".
+func (t *Table) BuildCreate(flavor flavors.Flavor, opts ...CreateOption) string {
+	o := newCreateOptions(flavor, "table "+t.Name, opts)
+	table := t
+	if o.temporary && hasTempSchema(flavor) {
+		// Temporary tables live in a schema of their own, and are named with a leading # on SQL Server
+		temp := *t
+		temp.Schema = ""
+		if flavor.Base() == flavors.SQLServer {
+			temp.Name = "#" + t.Name
+		}
+		table = &temp
+	}
+	stmts := table.buildCreate(flavor, nil, o)
+	return strings.Join(append(stmts, table.buildIndexes(flavor, o)...), ";\n")
+}
+
+// columnType returns the SQL type of a column.
+func (t *Table) columnType(flavor flavors.Flavor, col *Column[any]) string {
+	var sqlType string
+	if isEnum(col) {
+		sqlType = t.enumType(flavor, col)
+	} else if isCollection(col) {
+		sqlType = collectionType(flavor, col)
+	} else if col.AbstractType == ColumnTypeCQLCounter {
+		sqlType = counterType(flavor)
+	} else if col.Domain != "" && flavor.Base() == flavors.PostgreSQL {
+		sqlType = flavor.QuoteQualified(col.Domain)
+	} else {
+		sqlType = getTypeWithAuto(flavor, col)
+	}
+	if flavor.Base() == flavors.ClickHouse {
+		sqlType = clickHouseType(col, sqlType)
+	}
+	return sqlType
+}
+
+// columnDefinition renders a column as a table element. Its foreign key is a separate, named table
+// element, see foreignKeyConstraint.
+func (t *Table) columnDefinition(flavor flavors.Flavor, col *Column[any]) string {
+	checkVendorType(flavor, t.Name, col)
+	def := flavor.Quote(col.Name) + " " + t.columnType(flavor, col)
+	if col.Generated != nil {
+		def = generatedDefinition(flavor, t, col, def)
+	} else if col.DefaultExpr != nil {
+		expr := col.DefaultExpr.SQL(flavor)
+		// MySQL accepts parenthesized expression defaults from 8.0.13
+		if flavor.Base() == flavors.MySQL && flavor.Before("8.0.13") && strings.HasPrefix(expr, "(") {
+			warnf("default %s of column %s ignored: not supported by %s", expr, col.Name, flavor)
+		} else if expr != "" {
+			def += " DEFAULT " + expr
+		}
+	} else if col.HasDefault {
+		def += " DEFAULT " + formatDefault(flavor, col.Default)
+	} else if col.AutoIncrement && flavor.Base() == flavors.DuckDB {
+		def += " DEFAULT " + t.autoIncrementSequence(col).NextVal().SQL(flavor)
+	}
+	if col.OnUpdateNow && (flavor.Base() == flavors.MySQL || flavor.Base() == flavors.MariaDB || flavor.Base() == flavors.CockroachDB) {
+		def += " ON UPDATE CURRENT_TIMESTAMP"
+	}
+	if col.Comment != "" && supportsInlineComments(flavor) {
+		def += " COMMENT " + quoteLiteral(col.Comment)
+	}
+	return def
+}
+
+// autoIncrementSequence returns the sequence numbering an auto-increment column on DuckDB, which
+// has no auto-increment columns: <table>_<column>_seq, in the schema of the table.
+func (t *Table) autoIncrementSequence(col *Column[any]) Sequence {
+	return Sequence{Name: t.Name + "_" + col.Name + "_seq", Schema: t.Schema}
+}
+
+// buildAutoIncrementSequences builds the CREATE SEQUENCE statements for the auto-increment
+// columns of the table on DuckDB.
+func (t *Table) buildAutoIncrementSequences(flavor flavors.Flavor, opts createOptions) []string {
+	if flavor.Base() != flavors.DuckDB {
+		return nil
+	}
+	var stmts []string
+	for _, col := range t.Columns {
+		if col.AutoIncrement {
+			stmts = append(stmts, t.autoIncrementSequence(col).buildCreate(flavor, createOptions{ifNotExists: opts.ifNotExists, temporary: opts.temporary}))
 		}
-		buf.WriteString("'")
-		return buf.String()
 	}
-	panic(fmt.Sprintf("unsupported default type: %T", v))
+	return stmts
 }
 
-// BuildCreate builds the CREATE TABLE SQL for the given flavor.
-func (t *Table) BuildCreate(flavor flavors.Flavor) string {
+// buildCreate builds the statements creating the table without its indexes. The foreign keys of
+// deferred columns are left out, to be added once the referenced tables exist.
+func (t *Table) buildCreate(flavor flavors.Flavor, deferred map[*Column[any]]bool, opts createOptions) []string {
+	stmts := append(t.buildAutoIncrementSequences(flavor, opts), t.buildEnumTypes(flavor, opts)...)
+	stmts = append(stmts, t.buildCreateTable(flavor, t.quotedName(flavor), deferred, opts))
+	stmts = append(stmts, t.buildComments(flavor)...)
+	return append(stmts, t.buildOnUpdateTriggers(flavor, opts)...)
+}
+
+// buildCreateTable builds the CREATE TABLE statement alone, under the given quoted name.
+func (t *Table) buildCreateTable(flavor flavors.Flavor, name string, deferred map[*Column[any]]bool, opts createOptions) string {
 	builder := flavors.NewCreateTableBuilder(flavor)
-	builder.CreateTable(flavor.Quote(t.Name))
+	if opts.temporary && flavor.Base() != flavors.SQLServer {
+		builder.CreateTempTable(name)
+	} else {
+		builder.CreateTable(name)
+	}
+	if opts.unlogged {
+		builder.Unlogged()
+	}
+	if opts.orReplace {
+		builder.OrReplace()
+	}
+	if opts.ifNotExists {
+		builder.IfNotExists()
+	}
 	for _, col := range t.Columns {
-		sqlType := getTypeWithAuto(flavor, col)
-		def := flavor.Quote(col.Name) + " " + sqlType
-		if col.HasDefault {
-			def += " DEFAULT " + formatDefault(flavor, col.Default)
+		builder.Define(t.columnDefinition(flavor, col))
+	}
+	if pk := t.buildPrimaryKey(flavor); pk != "" {
+		builder.Define(pk)
+	}
+	if supportsForeignKeys(flavor) {
+		// Named table-level constraints, as MySQL ignores column-level REFERENCES
+		for _, col := range t.Columns {
+			if col.References != nil && !deferred[col] {
+				builder.Define(t.foreignKeyConstraint(flavor, col))
+			}
 		}
-		builder.Define(def)
+	}
+	if flavor.Base() == flavors.CQL {
+		if len(t.PartitionKey) == 0 {
+			panic(fmt.Sprintf("CQL table %s has no partition key", t.Name))
+		}
+		builder.Define(t.cqlPrimaryKey(flavor))
+	}
+	checks := t.CheckConstraints()
+	if len(checks) > 0 && !supportsCheckConstraints(flavor) {
+		for _, check := range checks {
+			warnf("CHECK constraint %s on table %s ignored: not supported by %s", check.Name, t.Name, flavor)
+		}
+	} else {
+		for _, check := range checks {
+			builder.Define(checkDefinition(flavor, check))
+		}
+	}
+	if opts := t.tableOptions(flavor); len(opts) > 0 {
+		// Joined by spaces, as ClickHouse does not accept the commas go-sqlbuilder puts between options
+		builder.Option(strings.Join(opts, " "))
 	}
 	sql, _ := builder.Build()
 	return sql
 }
+
+// foreignKeyName returns the name of the foreign key constraint of a column, <table>_<column>_fkey.
+func (t *Table) foreignKeyName(col *Column[any]) string {
+	return t.Name + "_" + col.Name + "_fkey"
+}
+
+// foreignKeyConstraint renders the foreign key of a column as a named table element.
+func (t *Table) foreignKeyConstraint(flavor flavors.Flavor, col *Column[any]) string {
+	fk := "FOREIGN KEY (" + flavor.Quote(col.Name) + ") REFERENCES " + flavor.QuoteQualified(col.References.Table) + " (" + flavor.Quote(col.References.Column) + ")"
+	if flavor.Base() == flavors.Informix {
+		// Informix names constraints after their definition
+		return fk + " CONSTRAINT " + flavor.Quote(t.foreignKeyName(col))
+	}
+	return "CONSTRAINT " + flavor.Quote(t.foreignKeyName(col)) + " " + fk
+}
+
+// buildAddForeignKey builds the ALTER TABLE statement adding the foreign key of a column.
+func (t *Table) buildAddForeignKey(flavor flavors.Flavor, col *Column[any]) string {
+	if flavor.Base() == flavors.Informix {
+		return "ALTER TABLE " + t.quotedName(flavor) + " ADD CONSTRAINT " + t.foreignKeyConstraint(flavor, col)
+	}
+	return "ALTER TABLE " + t.quotedName(flavor) + " ADD " + t.foreignKeyConstraint(flavor, col)
+}
+
+// buildDropForeignKey builds the ALTER TABLE statement dropping the foreign key added by buildAddForeignKey.
+func (t *Table) buildDropForeignKey(flavor flavors.Flavor, col *Column[any]) string {
+	if flavor.Base() == flavors.MySQL || flavor.Base() == flavors.MariaDB {
+		return "ALTER TABLE " + t.quotedName(flavor) + " DROP FOREIGN KEY " + flavor.Quote(t.foreignKeyName(col))
+	}
+	return "ALTER TABLE " + t.quotedName(flavor) + " DROP CONSTRAINT " + flavor.Quote(t.foreignKeyName(col))
+}